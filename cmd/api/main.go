@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"event-campus-backend/internal/config"
 	"event-campus-backend/internal/delivery/http/handler"
+	"event-campus-backend/internal/delivery/http/middleware"
 	"event-campus-backend/internal/delivery/http/router"
 	"event-campus-backend/internal/repository"
 	"event-campus-backend/internal/scheduler"
@@ -88,6 +89,11 @@ func main() {
 	eventRepo := repository.NewEventRepository(db)
 	registrationRepo := repository.NewRegistrationRepository(db)
 	attendanceRepo := repository.NewAttendanceRepository(db)
+	certificateRepo := repository.NewCertificateRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	loginFailureRepo := repository.NewLoginFailureRepository(db)
+	txManager := repository.NewTxManager(db)
 
 	// Parse JWT expiration
 	jwtExpiration, err := time.ParseDuration(cfg.JWT.Expiration)
@@ -95,6 +101,36 @@ func main() {
 		log.Fatalf("Invalid JWT expiration: %v", err)
 	}
 
+	refreshExpiration, err := time.ParseDuration(cfg.JWT.RefreshExpiration)
+	if err != nil {
+		log.Fatalf("Invalid refresh token expiration: %v", err)
+	}
+
+	verifyExpiration, err := time.ParseDuration(cfg.Auth.EmailVerificationExpiration)
+	if err != nil {
+		log.Fatalf("Invalid email verification expiration: %v", err)
+	}
+
+	resetExpiration, err := time.ParseDuration(cfg.Auth.PasswordResetExpiration)
+	if err != nil {
+		log.Fatalf("Invalid password reset expiration: %v", err)
+	}
+
+	loginBackoffBase, err := time.ParseDuration(cfg.Auth.LoginBackoffBase)
+	if err != nil {
+		log.Fatalf("Invalid login backoff base: %v", err)
+	}
+
+	loginLockoutDuration, err := time.ParseDuration(cfg.Auth.LoginLockoutDuration)
+	if err != nil {
+		log.Fatalf("Invalid login lockout duration: %v", err)
+	}
+
+	waitlistOfferTTL, err := time.ParseDuration(cfg.Waitlist.OfferTTL)
+	if err != nil {
+		log.Fatalf("Invalid waitlist offer TTL: %v", err)
+	}
+
 	// Initialize email sender
 	emailSender := utils.NewEmailSender(
 		cfg.Email.SMTPHost,
@@ -107,49 +143,124 @@ func main() {
 	fileUploader := utils.NewFileUploader(cfg.Upload.Path, cfg.Upload.MaxSize)
 
 	// Initialize use cases
-	authUsecase := usecase.NewAuthUsecase(userRepo, cfg.JWT.Secret, jwtExpiration)
+	authUsecase := usecase.NewAuthUsecase(
+		userRepo,
+		sessionRepo,
+		userTokenRepo,
+		loginFailureRepo,
+		emailSender,
+		cfg.JWT.Secret,
+		jwtExpiration,
+		refreshExpiration,
+		verifyExpiration,
+		resetExpiration,
+		cfg.Server.BaseURL,
+		cfg.Server.FrontendURL,
+		usecase.LoginPolicy{
+			MaxAttempts:     cfg.Auth.LoginMaxAttempts,
+			IPMaxAttempts:   cfg.Auth.LoginIPMaxAttempts,
+			BackoffBase:     loginBackoffBase,
+			LockoutDuration: loginLockoutDuration,
+		},
+	)
+	userUsecase := usecase.NewUserUsecase(
+		userRepo,
+		sessionRepo,
+		cfg.Server.BaseURL,
+	)
 	whitelistUsecase := usecase.NewWhitelistUsecase(
 		whitelistRepo,
 		userRepo,
+		txManager,
 		emailSender,
-		fmt.Sprintf("http://localhost:%s", cfg.Server.Port),
+		cfg.Server.BaseURL,
 	)
 	eventUsecase := usecase.NewEventUsecase(
 		eventRepo,
 		userRepo,
 		registrationRepo,
+		txManager,
 		emailSender,
-		fmt.Sprintf("http://localhost:%s", cfg.Server.Port),
+		cfg.Server.BaseURL,
+		cfg.CheckIn.TokenSecret,
 	)
 	registrationUsecase := usecase.NewRegistrationUsecase(
 		registrationRepo,
 		eventRepo,
 		userRepo,
+		txManager,
 		emailSender,
+		cfg.Auth.RequireEmailVerification,
+		usecase.WaitlistPolicy{
+			OfferMode: cfg.Waitlist.OfferMode,
+			OfferTTL:  waitlistOfferTTL,
+		},
+		cfg.CheckIn.TokenSecret,
+	)
+	adminUsecase := usecase.NewAdminUsecase(
+		userRepo,
+		sessionRepo,
+		loginFailureRepo,
+		registrationUsecase,
+		cfg.Server.BaseURL,
 	)
 	attendanceUsecase := usecase.NewAttendanceUsecase(
 		attendanceRepo,
 		eventRepo,
 		registrationRepo,
 		userRepo,
+		txManager,
+		cfg.CheckIn.TokenSecret,
+	)
+	certificateUsecase := usecase.NewCertificateUsecase(
+		certificateRepo,
+		eventRepo,
+		registrationRepo,
+		userRepo,
+		emailSender,
+		cfg.Upload.Path,
+		cfg.Server.BaseURL,
+		cfg.Certificate.SigningKey,
+	)
+	calendarUsecase := usecase.NewCalendarUsecase(
+		eventRepo,
+		userRepo,
+		cfg.Server.BaseURL,
 	)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUsecase)
+	userHandler := handler.NewUserHandler(userUsecase, fileUploader)
+	adminHandler := handler.NewAdminHandler(adminUsecase)
 	whitelistHandler := handler.NewWhitelistHandler(whitelistUsecase, fileUploader)
 	eventHandler := handler.NewEventHandler(eventUsecase, fileUploader)
 	registrationHandler := handler.NewRegistrationHandler(registrationUsecase)
 	attendanceHandler := handler.NewAttendanceHandler(attendanceUsecase)
+	certificateHandler := handler.NewCertificateHandler(certificateUsecase, fileUploader)
+	calendarHandler := handler.NewCalendarHandler(calendarUsecase)
+
+	// Rate limiting (disabled when store is nil)
+	var rateLimitStore middleware.RateLimitStore
+	if cfg.RateLimit.Enabled {
+		rateLimitStore = middleware.NewMemoryRateLimitStore()
+	}
 
 	// Setup router
 	r := router.NewRouter(
 		authHandler,
+		userHandler,
+		adminHandler,
 		whitelistHandler,
 		eventHandler,
 		registrationHandler,
 		attendanceHandler,
+		certificateHandler,
+		calendarHandler,
+		authUsecase,
 		cfg.JWT.Secret,
 		cfg.CORS.AllowedOrigins,
+		cfg.Server.TrustedProxies,
+		rateLimitStore,
 	)
 
 	// Initialize and start scheduler
@@ -157,7 +268,10 @@ func main() {
 		eventRepo,
 		registrationRepo,
 		userRepo,
+		registrationUsecase,
+		certificateUsecase,
 		emailSender,
+		cfg.CheckIn.TokenSecret,
 	)
 
 	if err := sched.Start(); err != nil {
@@ -166,7 +280,10 @@ func main() {
 	defer sched.Stop()
 
 	// Setup Gin engine
-	ginRouter := r.Setup()
+	ginRouter, err := r.Setup()
+	if err != nil {
+		log.Fatalf("Failed to set up router: %v", err)
+	}
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
@@ -176,7 +293,12 @@ func main() {
 	log.Println("Available endpoints:")
 	log.Println("  POST /api/v1/auth/register - User registration")
 	log.Println("  POST /api/v1/auth/login - User login")
+	log.Println("  POST /api/v1/auth/refresh - Refresh access token")
+	log.Println("  POST /api/v1/auth/logout - Logout current session (protected)")
+	log.Println("  POST /api/v1/auth/forgot-password - Request password reset link")
+	log.Println("  GET  /api/v1/auth/verify-email - Verify email address")
 	log.Println("  GET  /api/v1/profile - Get user profile (protected)")
+	log.Println("  PUT  /api/v1/profile - Update user profile (protected)")
 	log.Println("  GET  /health - Health check")
 	log.Println("")
 
@@ -23,9 +23,1917 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/users": {
+            "get": {
+                "description": "Get paginated list of users with optional search, role and status filters (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by full name or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (mahasiswa/organisasi/admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (active/suspended/deleted). Deleted users are hidden unless requested",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get users",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}": {
+            "get": {
+                "description": "Get detailed information about a user, including suspension and deletion status (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Soft-delete a user account. All sessions are revoked and registrations for upcoming events are cancelled (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or deletion failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}/revoke-organizer": {
+            "post": {
+                "description": "Demote an organisasi back to mahasiswa and clear its approval. All sessions of the user are revoked (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Revoke organizer status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organizer status revoked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or user is not an organizer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}/role": {
+            "patch": {
+                "description": "Change role of a user. Organisasi and admin roles granted here are approved directly. All sessions of the user are revoked (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Change user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangeRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or update failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "description": "Suspend a user account. All sessions are revoked and registrations for upcoming events are cancelled (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Suspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SuspendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User suspended successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or suspension failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "description": "Clear failed login attempts and lift the temporary lockout of a user account. IP based lockouts expire on their own (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unlock user login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login unlocked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or unlock failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/users/{id}/unsuspend": {
+            "post": {
+                "description": "Lift the suspension of a user account. Cancelled registrations are not restored (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unsuspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unsuspended successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or user not suspended",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Send a single-use password reset link to the email address. The response is the same whether or not the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset link sent if the account exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
-                "description": "Authenticate user and return JWT token",
+                "description": "Authenticate user and return a short-lived JWT access token plus a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful with JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, retry after the Retry-After header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke the session of the current access token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to logout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "description": "Revoke every session of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout from all devices",
+                "responses": {
+                    "200": {
+                        "description": "Logged out from all sessions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to logout",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token. The refresh token is rotated and the old one stops working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or revoked refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Registration successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or registration failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "description": "Send a new verification link to the authenticated user. Previous links stop working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Resend verification email",
+                "responses": {
+                    "200": {
+                        "description": "Verification email sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Email already verified or sending failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using the token from the reset email. All sessions of the user are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or invalid, used or expired token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "get": {
+                "description": "Verify the user's email address using the token from the verification email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, invalid, used or expired token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/{token}": {
+            "get": {
+                "description": "Subscribable iCalendar feed of the events the feed owner is registered for or attended, without authentication. The URL is created with POST /profile/calendar-feed",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token followed by .ics",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar feed not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/certificates/verify/{code}": {
+            "get": {
+                "description": "Check the authenticity of a certificate by its serial number, without authentication. Returns the participant, event and organizer the certificate was issued for and whether it was revoked. Pass the verification hash printed on the certificate to check that it matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certificates"
+                ],
+                "summary": "Verify certificate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Certificate serial number, e.g. EC-2024-K3QZ7M2XFA",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verification hash printed on the certificate",
+                        "name": "hash",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate found",
+                        "schema": {
+                            "$ref": "#/definitions/response.CertificateVerificationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Certificate not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Get paginated list of events with optional filters (category, status, event_type, search). Supports page/limit and cursor pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get all events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by category (seminar/workshop/lomba/konser)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft/published/ongoing/completed/cancelled), default published",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by event type (online/offline)",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by organizer (UUID)",
+                        "name": "organizer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by UII-only flag, false lists events open to everyone",
+                        "name": "is_uii_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events still running on or after this date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events starting on or before this date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events with seats left",
+                        "name": "has_capacity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events still accepting registrations",
+                        "name": "registration_open",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key (start_date/deadline/popularity/created, default start_date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc/desc, default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort, order or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get events",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new event (organizer only). Poster can be uploaded separately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Create a new event",
+                "parameters": [
+                    {
+                        "description": "Event details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Event created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or failed to create",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/my-events": {
+            "get": {
+                "description": "Get paginated list of events created by authenticated organizer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get my events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort key (start_date/deadline/popularity/created, default created)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc/desc, default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort, order or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get events",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/search": {
+            "get": {
+                "description": "Full-text search over event titles and descriptions (Indonesian and English), ranked by relevance with highlighted snippets. Falls back to typo-tolerant title matching when nothing matches. Accepts the same filters as listing, page/limit pagination only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Search events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms, supports quoted phrases, OR and -exclusions",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category (seminar/workshop/lomba/konser)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft/published/ongoing/completed/cancelled), default published",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by event type (online/offline)",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by organizer (UUID)",
+                        "name": "organizer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by UII-only flag, false lists events open to everyone",
+                        "name": "is_uii_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events still running on or after this date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events starting on or before this date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events with seats left",
+                        "name": "has_capacity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events still accepting registrations",
+                        "name": "registration_open",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query or invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to search events",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Update event details (organizer only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Update event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated event details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or update failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a draft event (organizer only). Published events have to be cancelled instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or deletion failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/attendance": {
+            "get": {
+                "description": "Get attendance list for a specific event (organizer only). With format=csv or format=xlsx, streams a spreadsheet of name, email, phone, status, registered/attended timestamps and notes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get event attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format instead of JSON",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or failed to get attendance",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Mark attendance for a single user at an event (organizer only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Mark single attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attendance details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MarkAttendanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance marked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or failed to mark",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/attendance/bulk": {
+            "post": {
+                "description": "Mark attendance for multiple users at once (organizer only). Returns a per-user result: marked, already_marked, not_registered, waitlisted, invalid_id or duplicate. All attendances are applied atomically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Mark bulk attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of user IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BulkMarkAttendanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-user results",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkAttendanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or failed to mark",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/attendance/history": {
+            "get": {
+                "description": "List every attendance change of an event, newest first: marks, note edits and removals with who made them and when (organizer only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get attendance history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance history retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or failed to get history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/attendance/import": {
+            "post": {
+                "description": "Mark attendance from a CSV with an email or registration_id column and optional notes column (organizer only). With dry_run=true, previews the result of every row without marking anything; otherwise all rows are applied atomically. At most 1000 rows and 1 MB",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Import attendance from CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attendance CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview without marking attendance",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-row results",
+                        "schema": {
+                            "$ref": "#/definitions/response.AttendanceImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or failed to import",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/attendance/{userId}": {
+            "delete": {
+                "description": "Undo a mistaken attendance mark (organizer only). The registration goes back to registered, an issued certificate is revoked and the removal is recorded in the attendance history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Remove attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance removed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or attendance not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Replace the notes of an attendance, an empty string clears them (organizer only). The change is recorded in the attendance history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Update attendance notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New notes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateAttendanceNotesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance notes updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or attendance not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/cancel": {
+            "post": {
+                "description": "Cancel a published or ongoing event with a reason (organizer only). All registrations are cancelled and registered and waitlisted users are notified by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Cancel event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CancelEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event cancelled successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or cancellation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/certificate-template": {
+            "get": {
+                "description": "Get the certificate background and field placements of an event (organizer only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certificates"
+                ],
+                "summary": "Get certificate template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate template retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or no template",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Upload the certificate design of an event (organizer only): a JPG/PNG background stretched over an A4 landscape page (297x210 mm) and a JSON array of field placements. Fields: participant_name, event_title, event_date, organizer_name, serial_number, verification_hash, verification_url; participant_name and serial_number are required. Without verification_hash or verification_url, a verification line is printed along the bottom edge. The background may be omitted to only change the fields",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Certificates"
+                ],
+                "summary": "Save certificate template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Background image (JPG/PNG), required for the first upload",
+                        "name": "background",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of {name, x, y, font_size, bold, color, align}",
+                        "name": "fields",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Certificate template saved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or fields",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/check-in": {
+            "post": {
+                "description": "Mark attendance by scanning the check-in QR code of a registration (organizer only). Opens 1 hour before the event starts; codes of other events and codes already used are rejected",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,95 +1941,288 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Attendance"
                 ],
-                "summary": "Login user",
+                "summary": "Check in with QR code",
                 "parameters": [
                     {
-                        "description": "Login credentials",
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scanned QR code token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
+                            "$ref": "#/definitions/request.CheckInRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful with JWT token",
+                        "description": "Checked in successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid, wrong-event or already used code",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/check-in-code": {
+            "get": {
+                "description": "Get the rotating self check-in code to display to participants (organizer only). The code changes every 30 seconds; poll again at valid_until",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Get self check-in code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current code",
+                        "schema": {
+                            "$ref": "#/definitions/response.SelfCheckInCodeResponse"
+                        }
                     },
-                    "401": {
-                        "description": "Authentication failed",
+                    "400": {
+                        "description": "Invalid event ID or self check-in not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/register": {
+        "/events/{id}/check-in-window": {
+            "post": {
+                "description": "Open a time-boxed window in which registered participants check themselves in with the rotating code (organizer only). Only while the event is running; the window never extends past the event's end",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Open self check-in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Window length",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.OpenCheckInWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Self check-in opened",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or event not running",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Close the self check-in window of an event early (organizer only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Close self check-in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Self check-in closed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or failed to close",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/ical": {
+            "get": {
+                "description": "Download an event as an iCalendar (.ics) file to add it to a calendar app. Draft events are only available to their organizer and admins",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Download event calendar file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/events/{id}/poster": {
             "post": {
-                "description": "Register a new user account with email and password",
+                "description": "Upload poster image for an event (organizer only)",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Events"
                 ],
-                "summary": "Register a new user",
+                "summary": "Upload event poster",
                 "parameters": [
                     {
-                        "description": "Registration details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image (JPG/PNG)",
+                        "name": "poster",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Registration successful",
+                        "description": "Poster uploaded successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request or registration failed",
+                        "description": "Invalid file or upload failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get list of events with optional filters (category, status, event_type, search)",
+                ]
+            }
+        },
+        "/events/{id}/publish": {
+            "post": {
+                "description": "Publish a draft event to make it visible to users",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,57 +2232,42 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "Get all events",
+                "summary": "Publish event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status (draft/published/ongoing/completed/cancelled)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by event type (online/offline/hybrid)",
-                        "name": "event_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name or description",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Events retrieved successfully",
+                        "description": "Event published successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Failed to get events",
+                    "400": {
+                        "description": "Invalid event ID or publish failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new event (organizer only). Poster can be uploaded separately.",
+                ]
+            }
+        },
+        "/events/{id}/register": {
+            "post": {
+                "description": "Register authenticated user for a specific event",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,89 +2275,101 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Registrations"
                 ],
-                "summary": "Create a new event",
+                "summary": "Register for an event",
                 "parameters": [
                     {
-                        "description": "Event details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateEventRequest"
-                        }
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Event created successfully",
+                        "description": "Registration successful",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request or failed to create",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid event ID or registration failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/my-events": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all events created by authenticated organizer",
+                ]
+            }
+        },
+        "/events/{id}/registrations": {
+            "get": {
+                "description": "Get all registrations for a specific event (organizer only). With format=csv or format=xlsx, streams a spreadsheet of name, email, phone, status, registered/attended timestamps and notes",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Events"
+                    "Registrations"
+                ],
+                "summary": "Get event registrations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format instead of JSON",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get my events",
                 "responses": {
                     "200": {
-                        "description": "Events retrieved successfully",
+                        "description": "Registrations retrieved successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Failed to get events",
+                    "400": {
+                        "description": "Invalid event ID or failed to get registrations",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get detailed information about a specific event",
+                ]
+            }
+        },
+        "/events/{id}/reminders": {
+            "post": {
+                "description": "Send H-1 reminder emails to all registered users manually (organizer only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -281,7 +2379,7 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "Get event by ID",
+                "summary": "Send manual reminders",
                 "parameters": [
                     {
                         "type": "string",
@@ -293,35 +2391,30 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event retrieved successfully",
+                        "description": "Reminders sent successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Event not found",
+                        "description": "Invalid event ID or failed to send",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update event details (organizer only)",
+                ]
+            }
+        },
+        "/events/{id}/self-check-in": {
+            "post": {
+                "description": "Check in to a running event by submitting the code displayed by the organizer. Requires an active registration and an open self check-in window. Offline events with a geofence also require the device latitude/longitude within check_in_radius_meters",
                 "consumes": [
                     "application/json"
                 ],
@@ -329,9 +2422,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Attendance"
                 ],
-                "summary": "Update event",
+                "summary": "Self check-in",
                 "parameters": [
                     {
                         "type": "string",
@@ -341,39 +2434,79 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Updated event details",
+                        "description": "Displayed check-in code",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateEventRequest"
+                            "$ref": "#/definitions/request.SelfCheckInRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event updated successfully",
+                        "description": "Checked in successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request or update failed",
+                        "description": "Invalid or expired code, window closed, outside the geofence or not registered",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/profile": {
+            "get": {
+                "description": "Get authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
                 ],
-                "description": "Delete an event (organizer only)",
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Update full name and phone number of the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -381,141 +2514,154 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "User"
                 ],
-                "summary": "Delete event",
+                "summary": "Update user profile",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Profile details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event deleted successfully",
+                        "description": "Profile updated successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID or deletion failed",
+                        "description": "Invalid request or update failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/attendance": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get attendance list for a specific event (organizer only)",
+                ]
+            }
+        },
+        "/profile/avatar": {
+            "post": {
+                "description": "Upload or replace the authenticated user's profile picture",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
-                ],
-                "summary": "Get event attendance",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
+                    "User"
+                ],
+                "summary": "Upload profile picture",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Profile picture (JPG/PNG)",
+                        "name": "avatar",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Attendance retrieved successfully",
+                        "description": "Avatar uploaded successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID or failed to get attendance",
+                        "description": "Invalid file or upload failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mark attendance for a single user at an event (organizer only)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/profile/calendar-feed": {
+            "post": {
+                "description": "Create the URL of the authenticated user's calendar feed, to subscribe to in a calendar app. The URL is only shown once; creating it again replaces the previous URL",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Calendar"
                 ],
-                "summary": "Mark single attendance",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "Create calendar feed",
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CalendarFeedResponse"
+                        }
                     },
-                    {
-                        "description": "Attendance details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Failed to create calendar feed",
                         "schema": {
-                            "$ref": "#/definitions/request.MarkAttendanceRequest"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Disable the authenticated user's calendar feed, its URL stops working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
                 ],
+                "summary": "Delete calendar feed",
                 "responses": {
                     "200": {
-                        "description": "Attendance marked successfully",
+                        "description": "Calendar feed deleted",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request or failed to mark",
+                        "description": "Failed to delete calendar feed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/attendance/bulk": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Mark attendance for multiple users at once (organizer only)",
+                ]
+            }
+        },
+        "/profile/password": {
+            "post": {
+                "description": "Change password of the authenticated user. Every other session is revoked, the current one stays logged in.",
                 "consumes": [
                     "application/json"
                 ],
@@ -523,105 +2669,121 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "User"
                 ],
-                "summary": "Mark bulk attendance",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "List of user IDs",
+                        "description": "Old and new password",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.BulkMarkAttendanceRequest"
+                            "$ref": "#/definitions/request.ChangePasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Bulk attendance marked successfully",
+                        "description": "Password changed successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request or failed to mark",
+                        "description": "Invalid request or wrong old password",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/poster": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Upload poster image for an event (organizer only)",
+                ]
+            }
+        },
+        "/registrations/my": {
+            "get": {
+                "description": "Get paginated list of registrations for authenticated user. Waitlisted registrations include waitlist_position, offered ones include offer_expires_at",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Registrations"
                 ],
-                "summary": "Upload event poster",
+                "summary": "Get my registrations",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Event ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Sort key (registered/start_date, default registered)",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Poster image (JPG/PNG)",
-                        "name": "poster",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Sort order (asc/desc, default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from the previous page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Poster uploaded successfully",
+                        "description": "Registrations retrieved successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid file or upload failed",
+                        "description": "Invalid sort, order or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get registrations",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/publish": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Publish a draft event to make it visible to users",
+                ]
+            }
+        },
+        "/registrations/{id}": {
+            "delete": {
+                "description": "Cancel user's registration for an event",
                 "consumes": [
                     "application/json"
                 ],
@@ -629,13 +2791,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Registrations"
                 ],
-                "summary": "Publish event",
+                "summary": "Cancel event registration",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID (UUID)",
+                        "description": "Registration ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -643,75 +2805,71 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Event published successfully",
+                        "description": "Registration cancelled successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID or publish failed",
+                        "description": "Invalid ID or cancellation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/register": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Register authenticated user for a specific event",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/registrations/{id}/certificate": {
+            "get": {
+                "description": "Download the PDF participation certificate of a registration (participant or event organizer). Available once the event is completed, to participants who attended, for events with a certificate template",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Registrations"
+                    "Certificates"
                 ],
-                "summary": "Register for an event",
+                "summary": "Download certificate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID (UUID)",
+                        "description": "Registration ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Registration successful",
+                    "200": {
+                        "description": "Certificate PDF",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID or registration failed",
+                        "description": "Invalid registration ID or certificate not available",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/events/{id}/registrations": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all registrations for a specific event (organizer only)",
+                ]
+            }
+        },
+        "/registrations/{id}/certificate/revoke": {
+            "post": {
+                "description": "Revoke the certificate of a registration with a reason (event organizer only). The certificate can no longer be downloaded and verification reports it as revoked",
                 "consumes": [
                     "application/json"
                 ],
@@ -719,44 +2877,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Registrations"
+                    "Certificates"
                 ],
-                "summary": "Get event registrations",
+                "summary": "Revoke certificate",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID (UUID)",
+                        "description": "Registration ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Revocation reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RevokeCertificateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Registrations retrieved successfully",
+                        "description": "Certificate revoked successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid event ID or failed to get registrations",
+                        "description": "Invalid request or revocation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/registrations/my": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all registrations for authenticated user",
+                ]
+            }
+        },
+        "/registrations/{id}/claim": {
+            "post": {
+                "description": "Confirm a seat offered to the authenticated user from the waitlist before its offer_expires_at deadline",
                 "consumes": [
                     "application/json"
                 ],
@@ -766,43 +2933,49 @@ const docTemplate = `{
                 "tags": [
                     "Registrations"
                 ],
-                "summary": "Get my registrations",
+                "summary": "Claim offered seat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Registration ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Registrations retrieved successfully",
+                        "description": "Seat claimed successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Failed to get registrations",
+                    "400": {
+                        "description": "Invalid ID, no offer or offer expired",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/registrations/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Cancel user's registration for an event",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/registrations/{id}/qr": {
+            "get": {
+                "description": "Get the check-in QR code of the authenticated user's registration as a PNG image. Organizers scan it at the door",
                 "produces": [
-                    "application/json"
+                    "image/png"
                 ],
                 "tags": [
                     "Registrations"
                 ],
-                "summary": "Cancel event registration",
+                "summary": "Get check-in QR code",
                 "parameters": [
                     {
                         "type": "string",
@@ -814,29 +2987,28 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Registration cancelled successfully",
+                        "description": "QR code PNG",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID or cancellation failed",
+                        "description": "Invalid ID or registration not active",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/whitelist/my-request": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/whitelist/my-request": {
+            "get": {
                 "description": "Get authenticated user's whitelist request status",
                 "consumes": [
                     "application/json"
@@ -870,16 +3042,16 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/whitelist/request": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
                 "description": "Submit request to become event organizer (requires PDF document)",
                 "consumes": [
                     "multipart/form-data"
@@ -922,16 +3094,16 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/whitelist/requests": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/whitelist/requests": {
+            "get": {
                 "description": "Get all whitelist requests with optional status filter (admin only)",
                 "consumes": [
                     "application/json"
@@ -966,16 +3138,16 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
-            }
-        },
-        "/whitelist/{id}/review": {
-            "patch": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/whitelist/{id}/review": {
+            "patch": {
                 "description": "Approve or reject whitelist request (admin only)",
                 "consumes": [
                     "application/json"
@@ -1020,7 +3192,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         }
     },
@@ -1033,12 +3210,69 @@ const docTemplate = `{
             "properties": {
                 "user_ids": {
                     "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
                 }
             }
         },
+        "request.CancelEventRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "request.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ChangeRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "mahasiswa",
+                        "organisasi",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "request.CheckInRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "request.CreateEventRequest": {
             "type": "object",
             "required": [
@@ -1062,6 +3296,11 @@ const docTemplate = `{
                         "konser"
                     ]
                 },
+                "check_in_radius_meters": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 10
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1078,9 +3317,19 @@ const docTemplate = `{
                 "is_uii_only": {
                     "type": "boolean"
                 },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
                 "location": {
                     "type": "string"
                 },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
                 "max_participants": {
                     "type": "integer",
                     "minimum": 1
@@ -1106,6 +3355,17 @@ const docTemplate = `{
                 }
             }
         },
+        "request.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
         "request.LoginRequest": {
             "type": "object",
             "required": [
@@ -1135,6 +3395,30 @@ const docTemplate = `{
                 }
             }
         },
+        "request.OpenCheckInWindowRequest": {
+            "type": "object",
+            "required": [
+                "duration_minutes"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 1
+                }
+            }
+        },
+        "request.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
         "request.RegisterRequest": {
             "type": "object",
             "required": [
@@ -1159,6 +3443,22 @@ const docTemplate = `{
                 }
             }
         },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "request.ReviewWhitelistRequest": {
             "type": "object",
             "required": [
@@ -1173,6 +3473,59 @@ const docTemplate = `{
                 }
             }
         },
+        "request.RevokeCertificateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "request.SelfCheckInRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "request.SuspendUserRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateAttendanceNotesRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
         "request.UpdateEventRequest": {
             "type": "object",
             "properties": {
@@ -1185,6 +3538,12 @@ const docTemplate = `{
                         "konser"
                     ]
                 },
+                "check_in_radius_meters": {
+                    "description": "0 removes the geofence",
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1201,9 +3560,19 @@ const docTemplate = `{
                 "is_uii_only": {
                     "type": "boolean"
                 },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
                 "location": {
                     "type": "string"
                 },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
                 "max_participants": {
                     "type": "integer",
                     "minimum": 1
@@ -1231,6 +3600,162 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "request.UpdateProfileRequest": {
+            "type": "object",
+            "required": [
+                "full_name",
+                "phone_number"
+            ],
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AttendanceImportResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "marked": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AttendanceImportRow"
+                    }
+                },
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "response.AttendanceImportRow": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "registration_id": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "will_mark (dry run), marked, already_marked, not_registered, waitlisted, invalid_id or duplicate",
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BulkAttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "marked": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BulkAttendanceResult"
+                    }
+                },
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "response.BulkAttendanceResult": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "description": "marked, already_marked, not_registered, waitlisted, invalid_id or duplicate",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CertificateVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "event_date": {
+                    "type": "string"
+                },
+                "event_title": {
+                    "type": "string"
+                },
+                "hash_matches": {
+                    "description": "only when a hash was submitted",
+                    "type": "boolean"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "organizer_name": {
+                    "type": "string"
+                },
+                "participant_name": {
+                    "type": "string"
+                },
+                "revocation_reason": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "not revoked, and the submitted hash matches if one was given",
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.SelfCheckInCodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                },
+                "window_closes_at": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
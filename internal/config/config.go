@@ -11,18 +11,26 @@ import (
 )
 
 type Config struct {
-	Server     ServerConfig
-	Supabase   SupabaseConfig
-	PostgreSQL PostgreSQLConfig
-	JWT        JWTConfig
-	Email      EmailConfig
-	Upload     UploadConfig
-	CORS       CORSConfig
+	Server      ServerConfig
+	Supabase    SupabaseConfig
+	PostgreSQL  PostgreSQLConfig
+	JWT         JWTConfig
+	Auth        AuthConfig
+	Email       EmailConfig
+	Upload      UploadConfig
+	CORS        CORSConfig
+	RateLimit   RateLimitConfig
+	Waitlist    WaitlistConfig
+	CheckIn     CheckInConfig
+	Certificate CertificateConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Env  string
+	Port           string
+	Env            string
+	BaseURL        string
+	FrontendURL    string
+	TrustedProxies []string // reverse proxies whose X-Forwarded-For is believed, none by default
 }
 
 type SupabaseConfig struct {
@@ -41,8 +49,19 @@ type PostgreSQLConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration string
+	Secret            string
+	Expiration        string
+	RefreshExpiration string
+}
+
+type AuthConfig struct {
+	RequireEmailVerification    bool
+	EmailVerificationExpiration string
+	PasswordResetExpiration     string
+	LoginMaxAttempts            int
+	LoginIPMaxAttempts          int
+	LoginBackoffBase            string
+	LoginLockoutDuration        string
 }
 
 type EmailConfig struct {
@@ -61,6 +80,23 @@ type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+type RateLimitConfig struct {
+	Enabled bool
+}
+
+type WaitlistConfig struct {
+	OfferMode bool
+	OfferTTL  string
+}
+
+type CheckInConfig struct {
+	TokenSecret string
+}
+
+type CertificateConfig struct {
+	SigningKey string
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	// Load .env file if it exists (ignore error in production)
@@ -76,10 +112,49 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid MAX_UPLOAD_SIZE: %w", err)
 	}
 
+	requireEmailVerification, err := strconv.ParseBool(getEnv("REQUIRE_EMAIL_VERIFICATION", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REQUIRE_EMAIL_VERIFICATION: %w", err)
+	}
+
+	loginMaxAttempts, err := strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_MAX_ATTEMPTS: %w", err)
+	}
+
+	loginIPMaxAttempts, err := strconv.Atoi(getEnv("LOGIN_IP_MAX_ATTEMPTS", "20"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_IP_MAX_ATTEMPTS: %w", err)
+	}
+
+	// Without trusted proxies the client IP is the peer address, X-Forwarded-For is ignored
+	var trustedProxies []string
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	rateLimitEnabled, err := strconv.ParseBool(getEnv("RATE_LIMIT_ENABLED", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ENABLED: %w", err)
+	}
+
+	waitlistOfferMode, err := strconv.ParseBool(getEnv("WAITLIST_OFFER_MODE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAITLIST_OFFER_MODE: %w", err)
+	}
+
+	port := getEnv("PORT", "8080")
+	jwtSecret := getEnvRequired("JWT_SECRET")
+
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Env:  getEnv("ENV", "development"),
+			Port:           port,
+			Env:            getEnv("ENV", "development"),
+			BaseURL:        strings.TrimRight(getEnv("BASE_URL", "http://localhost:"+port), "/"),
+			FrontendURL:    strings.TrimRight(getEnv("FRONTEND_URL", "http://localhost:3000"), "/"),
+			TrustedProxies: trustedProxies,
 		},
 		Supabase: SupabaseConfig{
 			URL:        getEnvRequired("SUPABASE_URL"),
@@ -95,8 +170,18 @@ func LoadConfig() (*Config, error) {
 			SSLMode:  getEnv("POSTGRES_SSLMODE", "require"),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnvRequired("JWT_SECRET"),
-			Expiration: getEnv("JWT_EXPIRATION", "24h"),
+			Secret:            jwtSecret,
+			Expiration:        getEnv("JWT_EXPIRATION", "15m"),
+			RefreshExpiration: getEnv("REFRESH_TOKEN_EXPIRATION", "720h"),
+		},
+		Auth: AuthConfig{
+			RequireEmailVerification:    requireEmailVerification,
+			EmailVerificationExpiration: getEnv("EMAIL_VERIFICATION_EXPIRATION", "48h"),
+			PasswordResetExpiration:     getEnv("PASSWORD_RESET_EXPIRATION", "1h"),
+			LoginMaxAttempts:            loginMaxAttempts,
+			LoginIPMaxAttempts:          loginIPMaxAttempts,
+			LoginBackoffBase:            getEnv("LOGIN_BACKOFF_BASE", "1s"),
+			LoginLockoutDuration:        getEnv("LOGIN_LOCKOUT_DURATION", "15m"),
 		},
 		Email: EmailConfig{
 			SMTPHost:     getEnv("SMTP_HOST", "smtp.gmail.com"),
@@ -111,6 +196,19 @@ func LoadConfig() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins: strings.Split(getEnv("ALLOWED_ORIGINS", "http://localhost:3000"), ","),
 		},
+		RateLimit: RateLimitConfig{
+			Enabled: rateLimitEnabled,
+		},
+		Waitlist: WaitlistConfig{
+			OfferMode: waitlistOfferMode,
+			OfferTTL:  getEnv("WAITLIST_OFFER_TTL", "24h"),
+		},
+		CheckIn: CheckInConfig{
+			TokenSecret: getEnv("CHECKIN_TOKEN_SECRET", jwtSecret),
+		},
+		Certificate: CertificateConfig{
+			SigningKey: getEnv("CERTIFICATE_SIGNING_KEY", jwtSecret),
+		},
 	}
 
 	return config, nil
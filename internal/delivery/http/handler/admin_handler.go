@@ -0,0 +1,390 @@
+package handler
+
+import (
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminHandler handles admin user management endpoints
+type AdminHandler struct {
+	adminUsecase usecase.AdminUsecase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminUsecase usecase.AdminUsecase) *AdminHandler {
+	return &AdminHandler{
+		adminUsecase: adminUsecase,
+	}
+}
+
+// ListUsers lists users with filters and pagination (admin only)
+// @Summary List users
+// @Description Get paginated list of users with optional search, role and status filters (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Search by full name or email"
+// @Param role query string false "Filter by role (mahasiswa/organisasi/admin)"
+// @Param status query string false "Filter by status (active/suspended/deleted). Deleted users are hidden unless requested"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Users retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 500 {object} map[string]interface{} "Failed to get users"
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	filter := repository.UserFilter{
+		Search: c.Query("search"),
+		Role:   c.Query("role"),
+		Status: c.Query("status"),
+		Page:   1,
+		Limit:  defaultPageLimit,
+	}
+
+	if filter.Role != "" && !domain.IsValidRole(filter.Role) {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid role filter",
+		})
+		return
+	}
+
+	if filter.Status != "" && filter.Status != repository.UserStatusActive &&
+		filter.Status != repository.UserStatusSuspended && filter.Status != repository.UserStatusDeleted {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid status filter",
+		})
+		return
+	}
+
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		filter.Limit = limit
+		if filter.Limit > maxPageLimit {
+			filter.Limit = maxPageLimit
+		}
+	}
+
+	users, meta, err := h.adminUsecase.ListUsers(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": "Failed to get users",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Users retrieved successfully",
+		"data":    users,
+		"meta":    meta,
+	})
+}
+
+// GetUser gets user detail (admin only)
+// @Summary Get user by ID
+// @Description Get detailed information about a user, including suspension and deletion status (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "User retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	user, err := h.adminUsecase.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "User not found",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "User retrieved successfully",
+		"data":    user,
+	})
+}
+
+// ChangeRole changes the role of a user (admin only)
+// @Summary Change user role
+// @Description Change role of a user. Organisasi and admin roles granted here are approved directly. All sessions of the user are revoked (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body request.ChangeRoleRequest true "New role"
+// @Success 200 {object} map[string]interface{} "Role updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or update failed"
+// @Router /admin/users/{id}/role [patch]
+func (h *AdminHandler) ChangeRole(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	adminIDInterface, _ := c.Get("userID")
+	adminID, _ := adminIDInterface.(uuid.UUID)
+
+	var req request.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	user, err := h.adminUsecase.ChangeRole(c.Request.Context(), adminID, userID, req.Role)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to update role",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Role updated successfully",
+		"data":    user,
+	})
+}
+
+// SuspendUser suspends a user account (admin only)
+// @Summary Suspend user
+// @Description Suspend a user account. All sessions are revoked and registrations for upcoming events are cancelled (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param request body request.SuspendUserRequest true "Suspension reason"
+// @Success 200 {object} map[string]interface{} "User suspended successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or suspension failed"
+// @Router /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	adminIDInterface, _ := c.Get("userID")
+	adminID, _ := adminIDInterface.(uuid.UUID)
+
+	var req request.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.adminUsecase.SuspendUser(c.Request.Context(), adminID, userID, req.Reason)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to suspend user",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "User suspended successfully",
+		"data":    result,
+	})
+}
+
+// UnsuspendUser lifts the suspension of a user account (admin only)
+// @Summary Unsuspend user
+// @Description Lift the suspension of a user account. Cancelled registrations are not restored (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "User unsuspended successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or user not suspended"
+// @Router /admin/users/{id}/unsuspend [post]
+func (h *AdminHandler) UnsuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	adminIDInterface, _ := c.Get("userID")
+	adminID, _ := adminIDInterface.(uuid.UUID)
+
+	user, err := h.adminUsecase.UnsuspendUser(c.Request.Context(), adminID, userID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to unsuspend user",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "User unsuspended successfully",
+		"data":    user,
+	})
+}
+
+// RevokeOrganizer revokes organizer status of a user (admin only)
+// @Summary Revoke organizer status
+// @Description Demote an organisasi back to mahasiswa and clear its approval. All sessions of the user are revoked (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Organizer status revoked successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or user is not an organizer"
+// @Router /admin/users/{id}/revoke-organizer [post]
+func (h *AdminHandler) RevokeOrganizer(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	adminIDInterface, _ := c.Get("userID")
+	adminID, _ := adminIDInterface.(uuid.UUID)
+
+	user, err := h.adminUsecase.RevokeOrganizer(c.Request.Context(), adminID, userID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to revoke organizer status",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Organizer status revoked successfully",
+		"data":    user,
+	})
+}
+
+// DeleteUser soft-deletes a user account (admin only)
+// @Summary Delete user
+// @Description Soft-delete a user account. All sessions are revoked and registrations for upcoming events are cancelled (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "User deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or deletion failed"
+// @Router /admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	adminIDInterface, _ := c.Get("userID")
+	adminID, _ := adminIDInterface.(uuid.UUID)
+
+	result, err := h.adminUsecase.DeleteUser(c.Request.Context(), adminID, userID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to delete user",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "User deleted successfully",
+		"data":    result,
+	})
+}
+
+// UnlockLogin clears the failed login lockout of a user (admin only)
+// @Summary Unlock user login
+// @Description Clear failed login attempts and lift the temporary lockout of a user account. IP based lockouts expire on their own (admin only)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Login unlocked successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or unlock failed"
+// @Router /admin/users/{id}/unlock [post]
+func (h *AdminHandler) UnlockLogin(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.adminUsecase.UnlockLogin(c.Request.Context(), userID); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to unlock login",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Login unlocked successfully",
+	})
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"event-campus-backend/internal/domain"
 	"event-campus-backend/internal/dto/request"
 	"event-campus-backend/internal/usecase"
 	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -87,14 +94,14 @@ func (h *AttendanceHandler) MarkAttendance(c *gin.Context) {
 
 // BulkMarkAttendance handles bulk attendance marking
 // @Summary Mark bulk attendance
-// @Description Mark attendance for multiple users at once (organizer only)
+// @Description Mark attendance for multiple users at once (organizer only). Returns a per-user result: marked, already_marked, not_registered, waitlisted, invalid_id or duplicate. All attendances are applied atomically
 // @Tags Attendance
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Event ID (UUID)"
 // @Param request body request.BulkMarkAttendanceRequest true "List of user IDs"
-// @Success 200 {object} map[string]interface{} "Bulk attendance marked successfully"
+// @Success 200 {object} response.BulkAttendanceResponse "Per-user results"
 // @Failure 400 {object} map[string]interface{} "Invalid request or failed to mark"
 // @Router /events/{id}/attendance/bulk [post]
 func (h *AttendanceHandler) BulkMarkAttendance(c *gin.Context) {
@@ -125,28 +132,388 @@ func (h *AttendanceHandler) BulkMarkAttendance(c *gin.Context) {
 		return
 	}
 
-	// Parse user IDs
-	var userIDs []uuid.UUID
-	for _, idStr := range req.UserIDs {
-		userID, err := uuid.Parse(idStr)
+	// Invalid IDs are reported per user rather than rejecting the batch
+	report, err := h.attendanceUsecase.BulkMarkAttendance(c.Request.Context(), organizerID, eventID, req.UserIDs)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to bulk mark attendance",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("Attendance marked for %d of %d users", report.Marked, len(req.UserIDs)),
+		"data":    report,
+	})
+}
+
+// attendanceImportMaxSize is the largest attendance CSV accepted for import
+const attendanceImportMaxSize = 1 << 20
+
+// attendanceImportFormOverhead is room for the multipart boundaries and part headers
+// around the CSV, on top of attendanceImportMaxSize
+const attendanceImportFormOverhead = 64 << 10
+
+// ImportAttendance handles attendance import from CSV
+// @Summary Import attendance from CSV
+// @Description Mark attendance from a CSV with an email or registration_id column and optional notes column (organizer only). With dry_run=true, previews the result of every row without marking anything; otherwise all rows are applied atomically. At most 1000 rows and 1 MB
+// @Tags Attendance
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param file formData file true "Attendance CSV"
+// @Param dry_run query bool false "Preview without marking attendance"
+// @Success 200 {object} response.AttendanceImportResponse "Per-row results"
+// @Failure 400 {object} map[string]interface{} "Invalid file or failed to import"
+// @Failure 413 {object} map[string]interface{} "File too large"
+// @Router /events/{id}/attendance/import [post]
+func (h *AttendanceHandler) ImportAttendance(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
 		if err != nil {
-			continue // Skip invalid IDs
+			c.JSON(400, gin.H{
+				"success": false,
+				"message": "Invalid dry_run, use true or false",
+			})
+			return
 		}
-		userIDs = append(userIDs, userID)
 	}
 
-	if len(userIDs) == 0 {
+	// Stop reading oversized uploads instead of spooling them to disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, attendanceImportMaxSize+attendanceImportFormOverhead)
+
+	// Get uploaded file
+	file, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(413, gin.H{
+			"success": false,
+			"message": "File too large. Maximum size is 1 MB",
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(400, gin.H{
 			"success": false,
-			"message": "No valid user IDs provided",
+			"message": "CSV file is required",
+			"error":   err.Error(),
 		})
 		return
 	}
 
-	if err := h.attendanceUsecase.BulkMarkAttendance(c.Request.Context(), organizerID, eventID, userIDs); err != nil {
+	// Validate file extension
+	if strings.ToLower(filepath.Ext(file.Filename)) != ".csv" {
 		c.JSON(400, gin.H{
 			"success": false,
-			"message": "Failed to bulk mark attendance",
+			"message": "Invalid file type. Only CSV is allowed",
+		})
+		return
+	}
+
+	if file.Size > attendanceImportMaxSize {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "File too large. Maximum size is 1 MB",
+		})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to read file",
+			"error":   err.Error(),
+		})
+		return
+	}
+	defer src.Close()
+
+	report, err := h.attendanceUsecase.ImportAttendance(c.Request.Context(), organizerID, eventID, src, dryRun)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to import attendance",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	message := fmt.Sprintf("Attendance marked for %d of %d rows", report.Marked, len(report.Rows))
+	if dryRun {
+		message = fmt.Sprintf("Import preview: %d of %d rows will be marked", report.Summary[domain.BulkAttendanceWillMark], len(report.Rows))
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": message,
+		"data":    report,
+	})
+}
+
+// CheckIn handles QR code check-in
+// @Summary Check in with QR code
+// @Description Mark attendance by scanning the check-in QR code of a registration (organizer only). Opens 1 hour before the event starts; codes of other events and codes already used are rejected
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param request body request.CheckInRequest true "Scanned QR code token"
+// @Success 200 {object} map[string]interface{} "Checked in successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid, wrong-event or already used code"
+// @Router /events/{id}/check-in [post]
+func (h *AttendanceHandler) CheckIn(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	// Parse request
+	var req request.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	attendance, err := h.attendanceUsecase.CheckIn(c.Request.Context(), organizerID, eventID, req.Token)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Check-in failed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Checked in successfully",
+		"data":    attendance,
+	})
+}
+
+// OpenCheckInWindow opens self check-in for an event
+// @Summary Open self check-in
+// @Description Open a time-boxed window in which registered participants check themselves in with the rotating code (organizer only). Only while the event is running; the window never extends past the event's end
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param request body request.OpenCheckInWindowRequest true "Window length"
+// @Success 200 {object} map[string]interface{} "Self check-in opened"
+// @Failure 400 {object} map[string]interface{} "Invalid request or event not running"
+// @Router /events/{id}/check-in-window [post]
+func (h *AttendanceHandler) OpenCheckInWindow(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	// Parse request
+	var req request.OpenCheckInWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	event, err := h.attendanceUsecase.OpenCheckInWindow(c.Request.Context(), organizerID, eventID, time.Duration(req.DurationMinutes)*time.Minute)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to open self check-in",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Self check-in opened",
+		"data": gin.H{
+			"check_in_opens_at":  event.CheckInOpensAt,
+			"check_in_closes_at": event.CheckInClosesAt,
+		},
+	})
+}
+
+// CloseCheckInWindow closes self check-in for an event
+// @Summary Close self check-in
+// @Description Close the self check-in window of an event early (organizer only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Self check-in closed"
+// @Failure 400 {object} map[string]interface{} "Invalid event ID or failed to close"
+// @Router /events/{id}/check-in-window [delete]
+func (h *AttendanceHandler) CloseCheckInWindow(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	if err := h.attendanceUsecase.CloseCheckInWindow(c.Request.Context(), organizerID, eventID); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to close self check-in",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Self check-in closed",
+	})
+}
+
+// GetSelfCheckInCode gets the current self check-in code
+// @Summary Get self check-in code
+// @Description Get the rotating self check-in code to display to participants (organizer only). The code changes every 30 seconds; poll again at valid_until
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {object} response.SelfCheckInCodeResponse "Current code"
+// @Failure 400 {object} map[string]interface{} "Invalid event ID or self check-in not open"
+// @Router /events/{id}/check-in-code [get]
+func (h *AttendanceHandler) GetSelfCheckInCode(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	code, err := h.attendanceUsecase.GetSelfCheckInCode(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to get check-in code",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Check-in code retrieved successfully",
+		"data":    code,
+	})
+}
+
+// SelfCheckIn handles participant self check-in
+// @Summary Self check-in
+// @Description Check in to a running event by submitting the code displayed by the organizer. Requires an active registration and an open self check-in window. Offline events with a geofence also require the device latitude/longitude within check_in_radius_meters
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param request body request.SelfCheckInRequest true "Displayed check-in code"
+// @Success 200 {object} map[string]interface{} "Checked in successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or expired code, window closed, outside the geofence or not registered"
+// @Failure 429 {object} map[string]interface{} "Too many attempts"
+// @Router /events/{id}/self-check-in [post]
+func (h *AttendanceHandler) SelfCheckIn(c *gin.Context) {
+	// Get user ID from context
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	// Parse request
+	var req request.SelfCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var location *usecase.GeoPoint
+	if req.Latitude != nil && req.Longitude != nil {
+		location = &usecase.GeoPoint{Latitude: *req.Latitude, Longitude: *req.Longitude}
+	}
+
+	attendance, err := h.attendanceUsecase.SelfCheckIn(c.Request.Context(), userID, eventID, req.Code, location)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Check-in failed",
 			"error":   err.Error(),
 		})
 		return
@@ -154,18 +521,184 @@ func (h *AttendanceHandler) BulkMarkAttendance(c *gin.Context) {
 
 	c.JSON(200, gin.H{
 		"success": true,
-		"message": fmt.Sprintf("Bulk attendance marked successfully for %d users", len(userIDs)),
+		"message": "Checked in successfully",
+		"data":    attendance,
+	})
+}
+
+// RemoveAttendance handles undoing an attendance mark
+// @Summary Remove attendance
+// @Description Undo a mistaken attendance mark (organizer only). The registration goes back to registered, an issued certificate is revoked and the removal is recorded in the attendance history
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Attendance removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID or attendance not found"
+// @Router /events/{id}/attendance/{userId} [delete]
+func (h *AttendanceHandler) RemoveAttendance(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	// Get user ID from URL
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	if err := h.attendanceUsecase.RemoveAttendance(c.Request.Context(), organizerID, eventID, userID); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to remove attendance",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Attendance removed successfully",
+	})
+}
+
+// UpdateAttendanceNotes handles correcting attendance notes
+// @Summary Update attendance notes
+// @Description Replace the notes of an attendance, an empty string clears them (organizer only). The change is recorded in the attendance history
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Param request body request.UpdateAttendanceNotesRequest true "New notes"
+// @Success 200 {object} map[string]interface{} "Attendance notes updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or attendance not found"
+// @Router /events/{id}/attendance/{userId} [patch]
+func (h *AttendanceHandler) UpdateAttendanceNotes(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	// Get user ID from URL
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid user ID",
+		})
+		return
+	}
+
+	// Parse request
+	var req request.UpdateAttendanceNotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var notes *string
+	if req.Notes != "" {
+		notes = &req.Notes
+	}
+
+	attendance, err := h.attendanceUsecase.UpdateAttendanceNotes(c.Request.Context(), organizerID, eventID, userID, notes)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to update attendance notes",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Attendance notes updated successfully",
+		"data":    attendance,
+	})
+}
+
+// GetAttendanceHistory gets the attendance audit trail of an event
+// @Summary Get attendance history
+// @Description List every attendance change of an event, newest first: marks, note edits and removals with who made them and when (organizer only)
+// @Tags Attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Attendance history retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid event ID or failed to get history"
+// @Router /events/{id}/attendance/history [get]
+func (h *AttendanceHandler) GetAttendanceHistory(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	history, err := h.attendanceUsecase.GetAttendanceHistory(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to get attendance history",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Attendance history retrieved successfully",
+		"data":    history,
 	})
 }
 
 // GetEventAttendance gets attendance list for event
 // @Summary Get event attendance
-// @Description Get attendance list for a specific event (organizer only)
+// @Description Get attendance list for a specific event (organizer only). With format=csv or format=xlsx, streams a spreadsheet of name, email, phone, status, registered/attended timestamps and notes
 // @Tags Attendance
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param id path string true "Event ID (UUID)"
+// @Param format query string false "Export format instead of JSON" Enums(csv, xlsx)
 // @Success 200 {object} map[string]interface{} "Attendance retrieved successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid event ID or failed to get attendance"
 // @Router /events/{id}/attendance [get]
@@ -186,6 +719,22 @@ func (h *AttendanceHandler) GetEventAttendance(c *gin.Context) {
 		return
 	}
 
+	format, ok := exportFormatFromQuery(c)
+	if !ok {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid format, use csv or xlsx",
+		})
+		return
+	}
+
+	if format != "" {
+		writeRegistrationExport(c, format, exportFilename("attendance", eventID), func(fn func(*domain.Registration) error) error {
+			return h.attendanceUsecase.ExportEventAttendance(c.Request.Context(), organizerID, eventID, fn)
+		})
+		return
+	}
+
 	attendances, err := h.attendanceUsecase.GetEventAttendance(c.Request.Context(), organizerID, eventID)
 	if err != nil {
 		c.JSON(400, gin.H{
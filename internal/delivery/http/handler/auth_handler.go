@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"event-campus-backend/internal/dto/request"
 	"event-campus-backend/internal/usecase"
+	"event-campus-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // AuthHandler handles authentication endpoints
@@ -41,7 +44,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authUsecase.Register(c.Request.Context(), &req)
+	resp, err := h.authUsecase.Register(c.Request.Context(), &req, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		c.JSON(400, gin.H{
 			"success": false,
@@ -60,7 +63,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login handles user login
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a short-lived JWT access token plus a refresh token
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -68,6 +71,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "Login successful with JWT token"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 401 {object} map[string]interface{} "Authentication failed"
+// @Failure 429 {object} map[string]interface{} "Too many failed attempts, retry after the Retry-After header"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
@@ -81,8 +85,22 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authUsecase.Login(c.Request.Context(), &req)
+	resp, err := h.authUsecase.Login(c.Request.Context(), &req, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
+		// Locked out logins carry their own status and retry delay
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			if appErr.RetryAfter > 0 {
+				c.Header("Retry-After", utils.RetryAfterSeconds(appErr.RetryAfter))
+			}
+			c.JSON(appErr.StatusCode, gin.H{
+				"success": false,
+				"message": "Login failed",
+				"error":   appErr.Error(),
+			})
+			return
+		}
+
 		c.JSON(401, gin.H{
 			"success": false,
 			"message": "Login failed",
@@ -97,3 +115,238 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		"data":    resp,
 	})
 }
+
+// Refresh handles access token renewal
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated and the old one stops working.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body request.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} map[string]interface{} "Token refreshed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid, expired or revoked refresh token"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req request.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.authUsecase.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		c.JSON(401, gin.H{
+			"success": false,
+			"message": "Token refresh failed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Token refreshed successfully",
+		"data":    resp,
+	})
+}
+
+// Logout handles logout of the current session
+// @Summary Logout
+// @Description Revoke the session of the current access token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Logout successful"
+// @Failure 500 {object} map[string]interface{} "Failed to logout"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	sessionIDInterface, _ := c.Get("sessionID")
+	sessionID, _ := sessionIDInterface.(uuid.UUID)
+
+	if err := h.authUsecase.Logout(c.Request.Context(), sessionID); err != nil {
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": "Failed to logout",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Logout successful",
+	})
+}
+
+// LogoutAll handles logout of every session of the user
+// @Summary Logout from all devices
+// @Description Revoke every session of the authenticated user
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Logged out from all sessions"
+// @Failure 500 {object} map[string]interface{} "Failed to logout"
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	if err := h.authUsecase.LogoutAll(c.Request.Context(), userID); err != nil {
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": "Failed to logout",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Logged out from all sessions",
+	})
+}
+
+// ForgotPassword handles password reset link requests
+// @Summary Request password reset
+// @Description Send a single-use password reset link to the email address. The response is the same whether or not the email is registered.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body request.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} map[string]interface{} "Reset link sent if the account exists"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Failed to process request"
+// @Router /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.authUsecase.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": "Failed to process request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+// ResetPassword handles password reset with an emailed token
+// @Summary Reset password
+// @Description Set a new password using the token from the reset email. All sessions of the user are revoked.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{} "Password reset successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or invalid, used or expired token"
+// @Router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.authUsecase.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Password reset failed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Password reset successfully, please login with your new password",
+	})
+}
+
+// VerifyEmail handles email verification links
+// @Summary Verify email address
+// @Description Verify the user's email address using the token from the verification email
+// @Tags Authentication
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} map[string]interface{} "Email verified successfully"
+// @Failure 400 {object} map[string]interface{} "Missing, invalid, used or expired token"
+// @Router /auth/verify-email [get]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Token is required",
+		})
+		return
+	}
+
+	if err := h.authUsecase.VerifyEmail(c.Request.Context(), token); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Email verification failed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Email verified successfully",
+	})
+}
+
+// ResendVerification handles resending of the verification email
+// @Summary Resend verification email
+// @Description Send a new verification link to the authenticated user. Previous links stop working.
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Verification email sent"
+// @Failure 400 {object} map[string]interface{} "Email already verified or sending failed"
+// @Router /auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	if err := h.authUsecase.ResendVerification(c.Request.Context(), userID); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to resend verification email",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Verification email sent",
+	})
+}
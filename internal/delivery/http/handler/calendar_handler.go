@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"event-campus-backend/internal/usecase"
+	"event-campus-backend/internal/utils"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CalendarHandler handles iCalendar endpoints
+type CalendarHandler struct {
+	calendarUsecase usecase.CalendarUsecase
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarUsecase usecase.CalendarUsecase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUsecase: calendarUsecase,
+	}
+}
+
+// GetEventCalendar handles downloading an event as an iCalendar file
+// @Summary Download event calendar file
+// @Description Download an event as an iCalendar (.ics) file to add it to a calendar app. Draft events are only available to their organizer and admins
+// @Tags Calendar
+// @Produce text/calendar
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {file} binary "iCalendar file"
+// @Failure 400 {object} map[string]interface{} "Invalid event ID"
+// @Failure 404 {object} map[string]interface{} "Event not found"
+// @Router /events/{id}/ical [get]
+func (h *CalendarHandler) GetEventCalendar(c *gin.Context) {
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+	roleInterface, _ := c.Get("userRole")
+	role, _ := roleInterface.(string)
+
+	calendar, err := h.calendarUsecase.GetEventCalendar(c.Request.Context(), eventID, userID, role)
+	if err != nil {
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "Event not found",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%s.ics"`, eventID))
+	c.Data(200, utils.ICalendarContentType, calendar)
+}
+
+// GetFeed handles the personal calendar feed
+// @Summary Calendar feed
+// @Description Subscribable iCalendar feed of the events the feed owner is registered for or attended, without authentication. The URL is created with POST /profile/calendar-feed
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token followed by .ics"
+// @Success 200 {file} binary "iCalendar feed"
+// @Failure 404 {object} map[string]interface{} "Calendar feed not found"
+// @Router /calendar/{token} [get]
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.calendarUsecase.GetFeed(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "Calendar feed not found",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(200, utils.ICalendarContentType, calendar)
+}
+
+// CreateFeed handles creating the personal calendar feed URL
+// @Summary Create calendar feed
+// @Description Create the URL of the authenticated user's calendar feed, to subscribe to in a calendar app. The URL is only shown once; creating it again replaces the previous URL
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.CalendarFeedResponse "Calendar feed created"
+// @Failure 400 {object} map[string]interface{} "Failed to create calendar feed"
+// @Router /profile/calendar-feed [post]
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	feed, err := h.calendarUsecase.CreateFeedToken(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to create calendar feed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Calendar feed created successfully",
+		"data":    feed,
+	})
+}
+
+// DeleteFeed handles disabling the personal calendar feed
+// @Summary Delete calendar feed
+// @Description Disable the authenticated user's calendar feed, its URL stops working
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Calendar feed deleted"
+// @Failure 400 {object} map[string]interface{} "Failed to delete calendar feed"
+// @Router /profile/calendar-feed [delete]
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	if err := h.calendarUsecase.DeleteFeedToken(c.Request.Context(), userID); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to delete calendar feed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Calendar feed deleted successfully",
+	})
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"encoding/json"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/usecase"
+	"event-campus-backend/internal/utils"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CertificateHandler handles certificate endpoints
+type CertificateHandler struct {
+	certificateUsecase usecase.CertificateUsecase
+	fileUploader       *utils.FileUploader
+}
+
+// NewCertificateHandler creates a new certificate handler
+func NewCertificateHandler(certificateUsecase usecase.CertificateUsecase, fileUploader *utils.FileUploader) *CertificateHandler {
+	return &CertificateHandler{
+		certificateUsecase: certificateUsecase,
+		fileUploader:       fileUploader,
+	}
+}
+
+// SaveTemplate handles certificate template upload
+// @Summary Save certificate template
+// @Description Upload the certificate design of an event (organizer only): a JPG/PNG background stretched over an A4 landscape page (297x210 mm) and a JSON array of field placements. Fields: participant_name, event_title, event_date, organizer_name, serial_number, verification_hash, verification_url; participant_name and serial_number are required. Without verification_hash or verification_url, a verification line is printed along the bottom edge. The background may be omitted to only change the fields
+// @Tags Certificates
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param background formData file false "Background image (JPG/PNG), required for the first upload"
+// @Param fields formData string true "JSON array of {name, x, y, font_size, bold, color, align}"
+// @Success 200 {object} map[string]interface{} "Certificate template saved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid file or fields"
+// @Router /events/{id}/certificate-template [put]
+func (h *CertificateHandler) SaveTemplate(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	var fields []domain.CertificateField
+	if err := json.Unmarshal([]byte(c.PostForm("fields")), &fields); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid fields, expected a JSON array of field placements",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Background is optional when only the fields change
+	var backgroundPath *string
+	if file, err := c.FormFile("background"); err == nil {
+		path, err := h.fileUploader.SaveCertificateBackground(file)
+		if err != nil {
+			c.JSON(400, gin.H{
+				"success": false,
+				"message": "Failed to upload background",
+				"error":   err.Error(),
+			})
+			return
+		}
+		backgroundPath = &path
+	}
+
+	template, oldBackgroundPath, err := h.certificateUsecase.SaveTemplate(c.Request.Context(), organizerID, eventID, backgroundPath, fields)
+	if err != nil {
+		// Delete uploaded file if saving fails
+		if backgroundPath != nil {
+			h.fileUploader.DeleteFile(*backgroundPath)
+		}
+
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to save certificate template",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Remove the replaced background
+	if oldBackgroundPath != nil {
+		h.fileUploader.DeleteFile(*oldBackgroundPath)
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Certificate template saved successfully",
+		"data":    template,
+	})
+}
+
+// GetTemplate gets the certificate template of an event
+// @Summary Get certificate template
+// @Description Get the certificate background and field placements of an event (organizer only)
+// @Tags Certificates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Certificate template retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid event ID or no template"
+// @Router /events/{id}/certificate-template [get]
+func (h *CertificateHandler) GetTemplate(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get event ID from URL
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	template, err := h.certificateUsecase.GetTemplate(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to get certificate template",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Certificate template retrieved successfully",
+		"data":    template,
+	})
+}
+
+// DownloadCertificate handles certificate download
+// @Summary Download certificate
+// @Description Download the PDF participation certificate of a registration (participant or event organizer). Available once the event is completed, to participants who attended, for events with a certificate template
+// @Tags Certificates
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Registration ID (UUID)"
+// @Success 200 {file} binary "Certificate PDF"
+// @Failure 400 {object} map[string]interface{} "Invalid registration ID or certificate not available"
+// @Router /registrations/{id}/certificate [get]
+func (h *CertificateHandler) DownloadCertificate(c *gin.Context) {
+	// Get user ID from context
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	// Get registration ID from URL
+	registrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid registration ID",
+		})
+		return
+	}
+
+	certificate, pdf, err := h.certificateUsecase.GetCertificatePDF(c.Request.Context(), userID, registrationID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to get certificate",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="sertifikat-%s.pdf"`, certificate.SerialNumber))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(200, "application/pdf", pdf)
+}
+
+// VerifyCertificate handles public certificate verification
+// @Summary Verify certificate
+// @Description Check the authenticity of a certificate by its serial number, without authentication. Returns the participant, event and organizer the certificate was issued for and whether it was revoked. Pass the verification hash printed on the certificate to check that it matches
+// @Tags Certificates
+// @Produce json
+// @Param code path string true "Certificate serial number, e.g. EC-2024-K3QZ7M2XFA"
+// @Param hash query string false "Verification hash printed on the certificate"
+// @Success 200 {object} response.CertificateVerificationResponse "Certificate found"
+// @Failure 404 {object} map[string]interface{} "Certificate not found"
+// @Router /certificates/verify/{code} [get]
+func (h *CertificateHandler) VerifyCertificate(c *gin.Context) {
+	verification, err := h.certificateUsecase.VerifyCertificate(c.Request.Context(), c.Param("code"), c.Query("hash"))
+	if err != nil {
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "Certificate not found",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Certificate found",
+		"data":    verification,
+	})
+}
+
+// RevokeCertificate handles certificate revocation
+// @Summary Revoke certificate
+// @Description Revoke the certificate of a registration with a reason (event organizer only). The certificate can no longer be downloaded and verification reports it as revoked
+// @Tags Certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Registration ID (UUID)"
+// @Param request body request.RevokeCertificateRequest true "Revocation reason"
+// @Success 200 {object} map[string]interface{} "Certificate revoked successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or revocation failed"
+// @Router /registrations/{id}/certificate/revoke [post]
+func (h *CertificateHandler) RevokeCertificate(c *gin.Context) {
+	// Get organizer ID from context
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	// Get registration ID from URL
+	registrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid registration ID",
+		})
+		return
+	}
+
+	var req request.RevokeCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	certificate, err := h.certificateUsecase.RevokeCertificate(c.Request.Context(), organizerID, registrationID, req.Reason)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to revoke certificate",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Certificate revoked successfully",
+		"data":    certificate,
+	})
+}
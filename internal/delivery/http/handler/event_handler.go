@@ -2,10 +2,12 @@ package handler
 
 import (
 	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/repository"
 	"event-campus-backend/internal/usecase"
 	"event-campus-backend/internal/utils"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -75,40 +77,124 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 // GetAllEvents gets list of events with filters
 // @Summary Get all events
-// @Description Get list of events with optional filters (category, status, event_type, search)
+// @Description Get paginated list of events with optional filters (category, status, event_type, search). Supports page/limit and cursor pagination
 // @Tags Events
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param category query string false "Filter by category"
-// @Param status query string false "Filter by status (draft/published/ongoing/completed/cancelled)"
-// @Param event_type query string false "Filter by event type (online/offline/hybrid)"
+// @Param category query string false "Filter by category (seminar/workshop/lomba/konser)"
+// @Param status query string false "Filter by status (draft/published/ongoing/completed/cancelled), default published"
+// @Param event_type query string false "Filter by event type (online/offline)"
 // @Param search query string false "Search by name or description"
+// @Param organizer_id query string false "Filter by organizer (UUID)"
+// @Param is_uii_only query bool false "Filter by UII-only flag, false lists events open to everyone"
+// @Param start_date query string false "Events still running on or after this date (YYYY-MM-DD)"
+// @Param end_date query string false "Events starting on or before this date (YYYY-MM-DD)"
+// @Param has_capacity query bool false "Only events with seats left"
+// @Param registration_open query bool false "Only events still accepting registrations"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Sort key (start_date/deadline/popularity/created, default start_date)"
+// @Param order query string false "Sort order (asc/desc, default desc)"
+// @Param cursor query string false "next_cursor from the previous page, replaces page"
 // @Success 200 {object} map[string]interface{} "Events retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter, sort, order or cursor"
 // @Failure 500 {object} map[string]interface{} "Failed to get events"
 // @Router /events [get]
 func (h *EventHandler) GetAllEvents(c *gin.Context) {
-	filters := make(map[string]interface{})
+	var req request.EventFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid filter",
+			"error":   err.Error(),
+		})
+		return
+	}
 
-	// Get query parameters
-	if category := c.Query("category"); category != "" {
-		filters["category"] = category
+	filter, err := eventFilterFromRequest(&req)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid organizer ID",
+		})
+		return
 	}
-	if status := c.Query("status"); status != "" {
-		filters["status"] = status
+
+	events, meta, err := h.eventUsecase.GetAllEvents(c.Request.Context(), filter, listOptionsFromQuery(c))
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"success": false,
+			"message": "Failed to get events",
+			"error":   err.Error(),
+		})
+		return
 	}
-	if eventType := c.Query("event_type"); eventType != "" {
-		filters["event_type"] = eventType
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Events retrieved successfully",
+		"data":    events,
+		"meta":    meta,
+	})
+}
+
+// SearchEvents searches events by relevance
+// @Summary Search events
+// @Description Full-text search over event titles and descriptions (Indonesian and English), ranked by relevance with highlighted snippets. Falls back to typo-tolerant title matching when nothing matches. Accepts the same filters as listing, page/limit pagination only
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search terms, supports quoted phrases, OR and -exclusions"
+// @Param category query string false "Filter by category (seminar/workshop/lomba/konser)"
+// @Param status query string false "Filter by status (draft/published/ongoing/completed/cancelled), default published"
+// @Param event_type query string false "Filter by event type (online/offline)"
+// @Param organizer_id query string false "Filter by organizer (UUID)"
+// @Param is_uii_only query bool false "Filter by UII-only flag, false lists events open to everyone"
+// @Param start_date query string false "Events still running on or after this date (YYYY-MM-DD)"
+// @Param end_date query string false "Events starting on or before this date (YYYY-MM-DD)"
+// @Param has_capacity query bool false "Only events with seats left"
+// @Param registration_open query bool false "Only events still accepting registrations"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Events found"
+// @Failure 400 {object} map[string]interface{} "Missing query or invalid filter"
+// @Failure 500 {object} map[string]interface{} "Failed to search events"
+// @Router /events/search [get]
+func (h *EventHandler) SearchEvents(c *gin.Context) {
+	var req request.EventSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil || strings.TrimSpace(req.Query) == "" {
+		errMsg := "search query is required"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid search",
+			"error":   errMsg,
+		})
+		return
 	}
-	if search := c.Query("search"); search != "" {
-		filters["search"] = search
+
+	filter, err := eventFilterFromRequest(&req.EventFilterRequest)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid organizer ID",
+		})
+		return
 	}
 
-	events, err := h.eventUsecase.GetAllEvents(c.Request.Context(), filters)
+	// Results are ordered by rank, sort and cursor do not apply
+	opts := listOptionsFromQuery(c)
+	opts.Sort, opts.Order, opts.Cursor = "", "", ""
+
+	events, meta, err := h.eventUsecase.SearchEvents(c.Request.Context(), req.Query, filter, opts)
 	if err != nil {
-		c.JSON(500, gin.H{
+		c.JSON(listErrorStatus(err), gin.H{
 			"success": false,
-			"message": "Failed to get events",
+			"message": "Failed to search events",
 			"error":   err.Error(),
 		})
 		return
@@ -116,11 +202,40 @@ func (h *EventHandler) GetAllEvents(c *gin.Context) {
 
 	c.JSON(200, gin.H{
 		"success": true,
-		"message": "Events retrieved successfully",
+		"message": "Events found",
 		"data":    events,
+		"meta":    meta,
 	})
 }
 
+// eventFilterFromRequest converts listing query parameters to a repository filter
+func eventFilterFromRequest(req *request.EventFilterRequest) (repository.EventFilter, error) {
+	filter := repository.EventFilter{
+		Category:         req.Category,
+		Status:           req.Status,
+		EventType:        req.EventType,
+		Search:           req.Search,
+		IsUIIOnly:        req.IsUIIOnly,
+		From:             req.StartDate,
+		HasCapacity:      req.HasCapacity,
+		RegistrationOpen: req.RegistrationOpen,
+	}
+	if req.OrganizerID != "" {
+		organizerID, err := uuid.Parse(req.OrganizerID)
+		if err != nil {
+			return filter, err
+		}
+		filter.OrganizerID = &organizerID
+	}
+	if req.EndDate != nil {
+		// end_date is inclusive, include events starting any time that day
+		to := req.EndDate.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	return filter, nil
+}
+
 // GetEvent gets event detail
 // @Summary Get event by ID
 // @Description Get detailed information about a specific event
@@ -163,21 +278,27 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 
 // GetMyEvents gets organizer's events
 // @Summary Get my events
-// @Description Get all events created by authenticated organizer
+// @Description Get paginated list of events created by authenticated organizer
 // @Tags Events
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Sort key (start_date/deadline/popularity/created, default created)"
+// @Param order query string false "Sort order (asc/desc, default desc)"
+// @Param cursor query string false "next_cursor from the previous page, replaces page"
 // @Success 200 {object} map[string]interface{} "Events retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid sort, order or cursor"
 // @Failure 500 {object} map[string]interface{} "Failed to get events"
 // @Router /events/my-events [get]
 func (h *EventHandler) GetMyEvents(c *gin.Context) {
 	organizerIDInterface, _ := c.Get("userID")
 	organizerID, _ := organizerIDInterface.(uuid.UUID)
 
-	events, err := h.eventUsecase.GetMyEvents(c.Request.Context(), organizerID)
+	events, meta, err := h.eventUsecase.GetMyEvents(c.Request.Context(), organizerID, listOptionsFromQuery(c))
 	if err != nil {
-		c.JSON(500, gin.H{
+		c.JSON(listErrorStatus(err), gin.H{
 			"success": false,
 			"message": "Failed to get events",
 			"error":   err.Error(),
@@ -189,6 +310,7 @@ func (h *EventHandler) GetMyEvents(c *gin.Context) {
 		"success": true,
 		"message": "Events retrieved successfully",
 		"data":    events,
+		"meta":    meta,
 	})
 }
 
@@ -327,7 +449,7 @@ func (h *EventHandler) UploadPoster(c *gin.Context) {
 
 // DeleteEvent handles event deletion
 // @Summary Delete event
-// @Description Delete an event (organizer only)
+// @Description Delete a draft event (organizer only). Published events have to be cancelled instead
 // @Tags Events
 // @Accept json
 // @Produce json
@@ -365,6 +487,56 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	})
 }
 
+// CancelEvent handles event cancellation
+// @Summary Cancel event
+// @Description Cancel a published or ongoing event with a reason (organizer only). All registrations are cancelled and registered and waitlisted users are notified by email
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Event ID (UUID)"
+// @Param request body request.CancelEventRequest true "Cancellation reason"
+// @Success 200 {object} map[string]interface{} "Event cancelled successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or cancellation failed"
+// @Router /events/{id}/cancel [post]
+func (h *EventHandler) CancelEvent(c *gin.Context) {
+	organizerIDInterface, _ := c.Get("userID")
+	organizerID, _ := organizerIDInterface.(uuid.UUID)
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid event ID",
+		})
+		return
+	}
+
+	var req request.CancelEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.eventUsecase.CancelEvent(c.Request.Context(), organizerID, eventID, req.Reason); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to cancel event",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Event cancelled successfully",
+	})
+}
+
 // PublishEvent handles event publishing
 // @Summary Publish event
 // @Description Publish a draft event to make it visible to users
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/utils"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// exportTimeFormat is how timestamps are written to spreadsheet exports
+const exportTimeFormat = "2006-01-02 15:04:05"
+
+// registrationExportHeader is the header row of registration and attendance exports
+var registrationExportHeader = []string{"Name", "Email", "Phone", "Status", "Registered At", "Attended At", "Notes"}
+
+// exportFormatFromQuery reads the format query parameter. An empty format means JSON;
+// ok is false for formats that are not supported.
+func exportFormatFromQuery(c *gin.Context) (format string, ok bool) {
+	format = strings.ToLower(c.Query("format"))
+	switch format {
+	case "", "json":
+		return "", true
+	case utils.ExportFormatCSV, utils.ExportFormatXLSX:
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// writeRegistrationExport streams the registrations passed by export to the client as a
+// spreadsheet download. Errors before anything was sent are answered with JSON; once the
+// download has started they can only cut it short.
+func writeRegistrationExport(c *gin.Context, format, filename string, export func(fn func(*domain.Registration) error) error) {
+	c.Header("Content-Type", utils.ExportContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	c.Header("Cache-Control", "private, no-store")
+
+	err := utils.WriteExport(c.Writer, format, registrationExportHeader, func(write func(values []string) error) error {
+		return export(func(registration *domain.Registration) error {
+			return write(registrationExportRow(registration))
+		})
+	})
+	if err == nil {
+		return
+	}
+
+	if c.Writer.Written() {
+		fmt.Printf("Failed to stream export: %v\n", err)
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	c.JSON(400, gin.H{
+		"success": false,
+		"message": "Failed to export",
+		"error":   err.Error(),
+	})
+}
+
+// registrationExportRow lays out a registration in the columns of registrationExportHeader
+func registrationExportRow(registration *domain.Registration) []string {
+	row := []string{
+		stringValue(registration.UserName),
+		stringValue(registration.UserEmail),
+		stringValue(registration.UserPhone),
+		registration.Status,
+		registration.RegisteredAt.Format(exportTimeFormat),
+		"",
+		stringValue(registration.AttendanceNotes),
+	}
+	if registration.AttendedAt != nil {
+		row[5] = registration.AttendedAt.Format(exportTimeFormat)
+	}
+	return row
+}
+
+// stringValue dereferences an optional string, empty when nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// exportFilename names an export of an event after what it contains and the export date
+func exportFilename(kind string, eventID uuid.UUID) string {
+	return fmt.Sprintf("%s-%s-%s", kind, eventID, time.Now().Format("20060102"))
+}
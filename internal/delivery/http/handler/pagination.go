@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"event-campus-backend/internal/repository"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// listOptionsFromQuery reads page, limit, sort, order and cursor query parameters.
+// Sort keys are validated by the repository.
+func listOptionsFromQuery(c *gin.Context) repository.ListOptions {
+	opts := repository.ListOptions{
+		Page:   1,
+		Limit:  defaultPageLimit,
+		Sort:   c.Query("sort"),
+		Order:  strings.ToLower(c.Query("order")),
+		Cursor: c.Query("cursor"),
+	}
+
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		opts.Limit = limit
+		if opts.Limit > maxPageLimit {
+			opts.Limit = maxPageLimit
+		}
+	}
+
+	return opts
+}
+
+// listErrorStatus maps a list error to 400 for bad pagination parameters, 500 otherwise
+func listErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrInvalidListOptions) {
+		return 400
+	}
+	return 500
+}
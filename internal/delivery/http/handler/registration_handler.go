@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"event-campus-backend/internal/domain"
 	"event-campus-backend/internal/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -108,14 +109,107 @@ func (h *RegistrationHandler) CancelRegistration(c *gin.Context) {
 	})
 }
 
+// ClaimOffer handles claiming a seat offered from the waitlist
+// @Summary Claim offered seat
+// @Description Confirm a seat offered to the authenticated user from the waitlist before its offer_expires_at deadline
+// @Tags Registrations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Registration ID (UUID)"
+// @Success 200 {object} map[string]interface{} "Seat claimed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid ID, no offer or offer expired"
+// @Router /registrations/{id}/claim [post]
+func (h *RegistrationHandler) ClaimOffer(c *gin.Context) {
+	// Get user ID from context
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	// Get registration ID from URL
+	registrationIDStr := c.Param("id")
+	registrationID, err := uuid.Parse(registrationIDStr)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid registration ID",
+		})
+		return
+	}
+
+	registration, err := h.registrationUsecase.ClaimOffer(c.Request.Context(), userID, registrationID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to claim seat",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Seat claimed successfully",
+		"data": gin.H{
+			"registration_id": registration.ID,
+			"status":          registration.Status,
+		},
+	})
+}
+
+// GetCheckInQRCode serves the check-in QR code of a registration
+// @Summary Get check-in QR code
+// @Description Get the check-in QR code of the authenticated user's registration as a PNG image. Organizers scan it at the door
+// @Tags Registrations
+// @Produce png
+// @Security BearerAuth
+// @Param id path string true "Registration ID (UUID)"
+// @Success 200 {file} binary "QR code PNG"
+// @Failure 400 {object} map[string]interface{} "Invalid ID or registration not active"
+// @Router /registrations/{id}/qr [get]
+func (h *RegistrationHandler) GetCheckInQRCode(c *gin.Context) {
+	// Get user ID from context
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	// Get registration ID from URL
+	registrationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid registration ID",
+		})
+		return
+	}
+
+	png, err := h.registrationUsecase.GetCheckInQRCode(c.Request.Context(), userID, registrationID)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to get QR code",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// The code stays valid for the whole registration, but don't leave it in shared caches
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(200, "image/png", png)
+}
+
 // GetMyRegistrations gets user's registrations
 // @Summary Get my registrations
-// @Description Get all registrations for authenticated user
+// @Description Get paginated list of registrations for authenticated user. Waitlisted registrations include waitlist_position, offered ones include offer_expires_at
 // @Tags Registrations
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 20, max 100)"
+// @Param sort query string false "Sort key (registered/start_date, default registered)"
+// @Param order query string false "Sort order (asc/desc, default desc)"
+// @Param cursor query string false "next_cursor from the previous page, replaces page"
 // @Success 200 {object} map[string]interface{} "Registrations retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid sort, order or cursor"
 // @Failure 500 {object} map[string]interface{} "Failed to get registrations"
 // @Router /registrations/my [get]
 func (h *RegistrationHandler) GetMyRegistrations(c *gin.Context) {
@@ -123,9 +217,9 @@ func (h *RegistrationHandler) GetMyRegistrations(c *gin.Context) {
 	userIDInterface, _ := c.Get("userID")
 	userID, _ := userIDInterface.(uuid.UUID)
 
-	registrations, err := h.registrationUsecase.GetMyRegistrations(c.Request.Context(), userID)
+	registrations, meta, err := h.registrationUsecase.GetMyRegistrations(c.Request.Context(), userID, listOptionsFromQuery(c))
 	if err != nil {
-		c.JSON(500, gin.H{
+		c.JSON(listErrorStatus(err), gin.H{
 			"success": false,
 			"message": "Failed to get registrations",
 			"error":   err.Error(),
@@ -137,17 +231,19 @@ func (h *RegistrationHandler) GetMyRegistrations(c *gin.Context) {
 		"success": true,
 		"message": "Registrations retrieved successfully",
 		"data":    registrations,
+		"meta":    meta,
 	})
 }
 
 // GetEventRegistrations gets event's registrations (organizer only)
 // @Summary Get event registrations
-// @Description Get all registrations for a specific event (organizer only)
+// @Description Get all registrations for a specific event (organizer only). With format=csv or format=xlsx, streams a spreadsheet of name, email, phone, status, registered/attended timestamps and notes
 // @Tags Registrations
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param id path string true "Event ID (UUID)"
+// @Param format query string false "Export format instead of JSON" Enums(csv, xlsx)
 // @Success 200 {object} map[string]interface{} "Registrations retrieved successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid event ID or failed to get registrations"
 // @Router /events/{id}/registrations [get]
@@ -167,6 +263,22 @@ func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 		return
 	}
 
+	format, ok := exportFormatFromQuery(c)
+	if !ok {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid format, use csv or xlsx",
+		})
+		return
+	}
+
+	if format != "" {
+		writeRegistrationExport(c, format, exportFilename("registrations", eventID), func(fn func(*domain.Registration) error) error {
+			return h.registrationUsecase.ExportEventRegistrations(c.Request.Context(), organizerID, eventID, fn)
+		})
+		return
+	}
+
 	registrations, err := h.registrationUsecase.GetEventRegistrations(c.Request.Context(), organizerID, eventID)
 	if err != nil {
 		c.JSON(400, gin.H{
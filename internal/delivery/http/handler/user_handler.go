@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/usecase"
+	"event-campus-backend/internal/utils"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UserHandler handles user profile endpoints
+type UserHandler struct {
+	userUsecase  usecase.UserUsecase
+	fileUploader *utils.FileUploader
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(userUsecase usecase.UserUsecase, fileUploader *utils.FileUploader) *UserHandler {
+	return &UserHandler{
+		userUsecase:  userUsecase,
+		fileUploader: fileUploader,
+	}
+}
+
+// GetProfile handles getting the authenticated user's profile
+// @Summary Get user profile
+// @Description Get authenticated user's profile information
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Profile retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /profile [get]
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	profile, err := h.userUsecase.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "User not found",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Profile retrieved",
+		"data":    profile,
+	})
+}
+
+// UpdateProfile handles updating the authenticated user's profile
+// @Summary Update user profile
+// @Description Update full name and phone number of the authenticated user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.UpdateProfileRequest true "Profile details"
+// @Success 200 {object} map[string]interface{} "Profile updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or update failed"
+// @Router /profile [put]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	var req request.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	profile, err := h.userUsecase.UpdateProfile(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to update profile",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Profile updated successfully",
+		"data":    profile,
+	})
+}
+
+// ChangePassword handles password change of the authenticated user
+// @Summary Change password
+// @Description Change password of the authenticated user. Every other session is revoked, the current one stays logged in.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ChangePasswordRequest true "Old and new password"
+// @Success 200 {object} map[string]interface{} "Password changed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request or wrong old password"
+// @Router /profile/password [post]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	sessionIDInterface, _ := c.Get("sessionID")
+	sessionID, _ := sessionIDInterface.(uuid.UUID)
+
+	var req request.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid request",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.userUsecase.ChangePassword(c.Request.Context(), userID, sessionID, &req); err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to change password",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Password changed successfully",
+	})
+}
+
+// UploadAvatar handles profile picture upload
+// @Summary Upload profile picture
+// @Description Upload or replace the authenticated user's profile picture
+// @Tags User
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param avatar formData file true "Profile picture (JPG/PNG)"
+// @Success 200 {object} map[string]interface{} "Avatar uploaded successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid file or upload failed"
+// @Router /profile/avatar [post]
+func (h *UserHandler) UploadAvatar(c *gin.Context) {
+	userIDInterface, _ := c.Get("userID")
+	userID, _ := userIDInterface.(uuid.UUID)
+
+	// Get uploaded file
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Avatar file is required",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Validate file extension
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Invalid file type. Only JPG and PNG are allowed",
+		})
+		return
+	}
+
+	// Save avatar
+	avatarPath, err := h.fileUploader.SaveAvatar(file)
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to upload avatar",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	profile, oldAvatarPath, err := h.userUsecase.UpdateAvatar(c.Request.Context(), userID, avatarPath)
+	if err != nil {
+		// Delete uploaded file if update fails
+		h.fileUploader.DeleteFile(avatarPath)
+
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "Failed to update avatar",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Remove the replaced avatar
+	if oldAvatarPath != nil {
+		h.fileUploader.DeleteFile(*oldAvatarPath)
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "Avatar uploaded successfully",
+		"data":    profile,
+	})
+}
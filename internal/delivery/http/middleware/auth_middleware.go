@@ -1,14 +1,21 @@
 package middleware
 
 import (
+	"context"
 	"event-campus-backend/internal/utils"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-// AuthMiddleware validates JWT token
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+// SessionValidator checks that the session and account behind an access token are still active
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, sessionID, userID uuid.UUID) error
+}
+
+// AuthMiddleware validates JWT token and the session it belongs to
+func AuthMiddleware(jwtSecret string, sessionValidator SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get authorization header
 		// Get authorization header
@@ -49,8 +56,21 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens whose session was revoked (logout, password change, etc.)
+		// or whose account was suspended or deleted
+		if err := sessionValidator.ValidateSession(c.Request.Context(), claims.SessionID, claims.UserID); err != nil {
+			c.JSON(401, gin.H{
+				"success": false,
+				"message": "Unauthorized",
+				"error":   err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
 		// Store user info in context
 		c.Set("userID", claims.UserID)
+		c.Set("sessionID", claims.SessionID)
 		c.Set("userEmail", claims.Email)
 		c.Set("userRole", claims.Role)
 
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/utils"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RateLimitPolicy describes a token bucket: up to Capacity requests in a burst,
+// with one token added back every RefillEvery
+type RateLimitPolicy struct {
+	Name        string // bucket namespace, routes sharing a name share their buckets
+	Capacity    int
+	RefillEvery time.Duration
+	KeyFunc     func(c *gin.Context) string
+}
+
+// RateLimitStore keeps token buckets. Implement it on top of a shared backend
+// (e.g. Redis) when running more than one API instance.
+type RateLimitStore interface {
+	// Take removes a token from the bucket identified by key. It reports whether
+	// the request is allowed, how many tokens are left and, when denied, how long
+	// until the next token is available.
+	Take(ctx context.Context, key string, capacity int, refillEvery time.Duration) (allowed bool, remaining int, retryAfter time.Duration, err error)
+}
+
+// KeyByIP identifies the client by IP address
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByUserOrIP identifies the client by authenticated user, falling back to IP address.
+// Use it after AuthMiddleware so the user ID is available.
+func KeyByUserOrIP(c *gin.Context) string {
+	if userIDInterface, exists := c.Get("userID"); exists {
+		if userID, ok := userIDInterface.(uuid.UUID); ok && userID != uuid.Nil {
+			return "user:" + userID.String()
+		}
+	}
+	return KeyByIP(c)
+}
+
+// refreshTokenBodyLimit caps how much of the request body KeyByRefreshToken reads
+const refreshTokenBodyLimit = 4096
+
+// KeyByRefreshToken identifies the session by the refresh token in the JSON body,
+// falling back to IP address. The body is restored for the handler.
+func KeyByRefreshToken(c *gin.Context) string {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, refreshTokenBodyLimit))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil {
+		return KeyByIP(c)
+	}
+
+	var req request.RefreshTokenRequest
+	if json.Unmarshal(body, &req) != nil || req.RefreshToken == "" {
+		return KeyByIP(c)
+	}
+
+	return "session:" + utils.HashToken(req.RefreshToken)
+}
+
+// RateLimit limits requests according to policy. A nil store disables limiting.
+func RateLimit(store RateLimitStore, policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if store == nil {
+			c.Next()
+			return
+		}
+
+		key := policy.Name + ":" + policy.KeyFunc(c)
+
+		allowed, remaining, retryAfter, err := store.Take(c.Request.Context(), key, policy.Capacity, policy.RefillEvery)
+		if err != nil {
+			// Fail open, an unavailable store must not take the API down
+			fmt.Printf("Rate limit store error: %v\n", err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(policy.Capacity))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			seconds := utils.RetryAfterSeconds(retryAfter)
+			c.Header("Retry-After", seconds)
+			c.JSON(429, response.ErrorResponse(
+				"Too many requests",
+				fmt.Sprintf("rate limit exceeded, retry in %s seconds", seconds),
+			))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// memoryBucket is a single token bucket
+type memoryBucket struct {
+	tokens      float64
+	capacity    int
+	refillEvery time.Duration
+	updatedAt   time.Time
+}
+
+// refill adds the tokens earned since the last update
+func (b *memoryBucket) refill(now time.Time) {
+	earned := float64(now.Sub(b.updatedAt)) / float64(b.refillEvery)
+	b.tokens += earned
+	if b.tokens > float64(b.capacity) {
+		b.tokens = float64(b.capacity)
+	}
+	b.updatedAt = now
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory
+type MemoryRateLimitStore struct {
+	mu         sync.Mutex
+	buckets    map[string]*memoryBucket
+	lastSweep  time.Time
+	sweepEvery time.Duration
+	now        func() time.Time // replaced in tests
+}
+
+// NewMemoryRateLimitStore creates a new in-memory rate limit store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:    make(map[string]*memoryBucket),
+		lastSweep:  time.Now(),
+		sweepEvery: time.Minute,
+		now:        time.Now,
+	}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, capacity int, refillEvery time.Duration) (bool, int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &memoryBucket{
+			tokens:      float64(capacity),
+			capacity:    capacity,
+			refillEvery: refillEvery,
+			updatedAt:   now,
+		}
+		s.buckets[key] = bucket
+	}
+
+	bucket.refill(now)
+
+	if bucket.tokens < 1 {
+		retryAfter := time.Duration((1 - bucket.tokens) * float64(refillEvery))
+		return false, 0, retryAfter, nil
+	}
+
+	bucket.tokens--
+	return true, int(bucket.tokens), 0, nil
+}
+
+// sweep drops buckets that have refilled completely, they behave exactly like new ones
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.sweepEvery {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.capacity) {
+			delete(s.buckets, key)
+		}
+	}
+}
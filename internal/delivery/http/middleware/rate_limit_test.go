@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestStore returns a memory store whose clock only moves when advance is called
+func newTestStore() (*MemoryRateLimitStore, func(time.Duration)) {
+	store := NewMemoryRateLimitStore()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	store.lastSweep = now
+	store.now = func() time.Time { return now }
+
+	return store, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	store, advance := newTestStore()
+	ctx := context.Background()
+	const refill = 10 * time.Second
+
+	// A full bucket allows a burst of capacity requests
+	for want := 2; want >= 0; want-- {
+		allowed, remaining, _, err := store.Take(ctx, "key", 3, refill)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if !allowed || remaining != want {
+			t.Fatalf("allowed = %v, remaining = %d, want allowed with %d remaining", allowed, remaining, want)
+		}
+	}
+
+	steps := []struct {
+		advance    time.Duration
+		allowed    bool
+		retryAfter time.Duration
+	}{
+		{0, false, refill},                        // empty, the next token is a full refill away
+		{4 * time.Second, false, 6 * time.Second}, // part of the way there
+		{6 * time.Second, true, 0},                // one token earned
+		{0, false, refill},                        // and spent again
+	}
+	for i, step := range steps {
+		advance(step.advance)
+		allowed, remaining, retryAfter, err := store.Take(ctx, "key", 3, refill)
+		if err != nil {
+			t.Fatalf("step %d: Take: %v", i, err)
+		}
+		if allowed != step.allowed || retryAfter != step.retryAfter || remaining != 0 {
+			t.Errorf("step %d: allowed = %v, remaining = %d, retryAfter = %s, want %v, 0, %s",
+				i, allowed, remaining, retryAfter, step.allowed, step.retryAfter)
+		}
+	}
+
+	// Buckets are independent per key
+	if allowed, _, _, _ := store.Take(ctx, "other", 3, refill); !allowed {
+		t.Error("another key was limited by a full bucket")
+	}
+
+	// A long wait refills no more than capacity
+	advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if allowed, _, _, _ := store.Take(ctx, "key", 3, refill); !allowed {
+			t.Fatalf("request %d after refilling was denied", i+1)
+		}
+	}
+	if allowed, _, _, _ := store.Take(ctx, "key", 3, refill); allowed {
+		t.Error("bucket refilled beyond its capacity")
+	}
+}
+
+func newRateLimitedEngine(store RateLimitStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.GET("/limited", RateLimit(store, RateLimitPolicy{
+		Name:        "test",
+		Capacity:    1,
+		RefillEvery: 10 * time.Second,
+		KeyFunc:     KeyByIP,
+	}), func(c *gin.Context) {
+		c.JSON(200, gin.H{"success": true})
+	})
+
+	return engine
+}
+
+func serve(engine *gin.Engine) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	request.RemoteAddr = "203.0.113.7:4321"
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRateLimitRejectsWith429(t *testing.T) {
+	store, advance := newTestStore()
+	engine := newRateLimitedEngine(store)
+
+	first := serve(engine)
+	if first.Code != 200 {
+		t.Fatalf("first request status = %d, want 200", first.Code)
+	}
+	if got := first.Header().Get("X-RateLimit-Limit"); got != "1" {
+		t.Errorf("X-RateLimit-Limit = %q, want 1", got)
+	}
+	if got := first.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+
+	advance(2500 * time.Millisecond)
+	limited := serve(engine)
+	if limited.Code != 429 {
+		t.Fatalf("second request status = %d, want 429", limited.Code)
+	}
+	// 7.5 seconds left, rounded up
+	if got := limited.Header().Get("Retry-After"); got != "8" {
+		t.Errorf("Retry-After = %q, want 8", got)
+	}
+
+	var body response.BaseResponse
+	if err := json.Unmarshal(limited.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	want := response.ErrorResponse("Too many requests", "rate limit exceeded, retry in 8 seconds")
+	if body != want {
+		t.Errorf("body = %+v, want %+v", body, want)
+	}
+
+	advance(7500 * time.Millisecond)
+	if got := serve(engine).Code; got != 200 {
+		t.Errorf("request after refill status = %d, want 200", got)
+	}
+}
+
+// failingStore is a rate limit store whose backend is down
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, capacity int, refillEvery time.Duration) (bool, int, time.Duration, error) {
+	return false, 0, 0, errors.New("store unavailable")
+}
+
+func TestRateLimitPassesWithoutStore(t *testing.T) {
+	for name, store := range map[string]RateLimitStore{"disabled": nil, "failing": failingStore{}} {
+		engine := newRateLimitedEngine(store)
+		for i := 0; i < 3; i++ {
+			if got := serve(engine).Code; got != 200 {
+				t.Errorf("%s store: request %d status = %d, want 200", name, i+1, got)
+			}
+		}
+	}
+}
+
+func TestKeyByRefreshTokenRestoresBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"refresh_token":"abc123"}`
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", strings.NewReader(body))
+
+	if got, want := KeyByRefreshToken(c), "session:"+utils.HashToken("abc123"); got != want {
+		t.Errorf("key = %q, want %q", got, want)
+	}
+
+	restored, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(restored) != body {
+		t.Errorf("body = %q, want %q", restored, body)
+	}
+}
+
+func TestKeyByRefreshTokenFallsBackToIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, body := range []string{"", "not json", `{"refresh_token":""}`} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", strings.NewReader(body))
+		c.Request.RemoteAddr = "203.0.113.7:4321"
+
+		if got := KeyByRefreshToken(c); got != "ip:203.0.113.7" {
+			t.Errorf("body %q: key = %q, want ip:203.0.113.7", body, got)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package router
 import (
 	"event-campus-backend/internal/delivery/http/handler"
 	"event-campus-backend/internal/delivery/http/middleware"
+	"fmt"
+	"time"
 
 	_ "event-campus-backend/docs" // Swagger docs
 
@@ -14,39 +16,66 @@ import (
 // Router holds all HTTP handlers
 type Router struct {
 	authHandler         *handler.AuthHandler
+	userHandler         *handler.UserHandler
+	adminHandler        *handler.AdminHandler
 	whitelistHandler    *handler.WhitelistHandler
 	eventHandler        *handler.EventHandler
 	registrationHandler *handler.RegistrationHandler
 	attendanceHandler   *handler.AttendanceHandler
+	certificateHandler  *handler.CertificateHandler
+	calendarHandler     *handler.CalendarHandler
+	sessionValidator    middleware.SessionValidator
 	jwtSecret           string
 	corsOrigins         []string
+	trustedProxies      []string
+	rateLimitStore      middleware.RateLimitStore
 }
 
 // NewRouter creates a new router
 func NewRouter(
 	authHandler *handler.AuthHandler,
+	userHandler *handler.UserHandler,
+	adminHandler *handler.AdminHandler,
 	whitelistHandler *handler.WhitelistHandler,
 	eventHandler *handler.EventHandler,
 	registrationHandler *handler.RegistrationHandler,
 	attendanceHandler *handler.AttendanceHandler,
+	certificateHandler *handler.CertificateHandler,
+	calendarHandler *handler.CalendarHandler,
+	sessionValidator middleware.SessionValidator,
 	jwtSecret string,
 	corsOrigins []string,
+	trustedProxies []string,
+	rateLimitStore middleware.RateLimitStore,
 ) *Router {
 	return &Router{
 		authHandler:         authHandler,
+		userHandler:         userHandler,
+		adminHandler:        adminHandler,
 		whitelistHandler:    whitelistHandler,
 		eventHandler:        eventHandler,
 		registrationHandler: registrationHandler,
 		attendanceHandler:   attendanceHandler,
+		certificateHandler:  certificateHandler,
+		calendarHandler:     calendarHandler,
+		sessionValidator:    sessionValidator,
 		jwtSecret:           jwtSecret,
 		corsOrigins:         corsOrigins,
+		trustedProxies:      trustedProxies,
+		rateLimitStore:      rateLimitStore,
 	}
 }
 
 // Setup sets up all routes
-func (r *Router) Setup() *gin.Engine {
+func (r *Router) Setup() (*gin.Engine, error) {
 	router := gin.Default()
 
+	// Client IPs key the rate limits and login lockouts, so X-Forwarded-For is
+	// only believed when it was set by our own reverse proxy
+	if err := router.SetTrustedProxies(r.trustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// CORS middleware
 	router.Use(middleware.CORSMiddleware(r.corsOrigins))
 
@@ -62,40 +91,102 @@ func (r *Router) Setup() *gin.Engine {
 	// Access via: /docs/index.html
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	authMiddleware := middleware.AuthMiddleware(r.jwtSecret, r.sessionValidator)
+
+	// Rate limits: burst of Capacity requests, one more every RefillEvery
+	authRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "auth",
+		Capacity:    10,
+		RefillEvery: 6 * time.Second,
+		KeyFunc:     middleware.KeyByIP,
+	})
+	// Refreshing is limited per session, so users behind one NAT do not share a bucket
+	refreshRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "refresh",
+		Capacity:    20,
+		RefillEvery: 3 * time.Second,
+		KeyFunc:     middleware.KeyByRefreshToken,
+	})
+	apiRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "api",
+		Capacity:    100,
+		RefillEvery: 600 * time.Millisecond,
+		KeyFunc:     middleware.KeyByUserOrIP,
+	})
+	eventRegisterRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "event-register",
+		Capacity:    5,
+		RefillEvery: 12 * time.Second,
+		KeyFunc:     middleware.KeyByUserOrIP,
+	})
+	// Self check-in codes are short, so guessing has to stay slow
+	selfCheckInRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "self-check-in",
+		Capacity:    5,
+		RefillEvery: 12 * time.Second,
+		KeyFunc:     middleware.KeyByUserOrIP,
+	})
+	// Verification is public, so it is limited per IP
+	certificateVerifyRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "certificate-verify",
+		Capacity:    30,
+		RefillEvery: 2 * time.Second,
+		KeyFunc:     middleware.KeyByIP,
+	})
+	// Calendar apps poll feeds every few minutes at most
+	calendarFeedRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "calendar-feed",
+		Capacity:    30,
+		RefillEvery: 10 * time.Second,
+		KeyFunc:     middleware.KeyByIP,
+	})
+	reminderRateLimit := middleware.RateLimit(r.rateLimitStore, middleware.RateLimitPolicy{
+		Name:        "reminders",
+		Capacity:    3,
+		RefillEvery: 20 * time.Minute,
+		KeyFunc:     middleware.KeyByUserOrIP,
+	})
+
 	// API v1
 	v1 := router.Group("/api/v1")
 	{
-		// Authentication routes (public)
+		// Authentication routes
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/register", r.authHandler.Register)
-			auth.POST("/login", r.authHandler.Login)
+			// Credential and email endpoints share the strict per-IP limit
+			auth.POST("/register", authRateLimit, r.authHandler.Register)
+			auth.POST("/login", authRateLimit, r.authHandler.Login)
+			auth.POST("/forgot-password", authRateLimit, r.authHandler.ForgotPassword)
+			auth.POST("/reset-password", authRateLimit, r.authHandler.ResetPassword)
+			auth.GET("/verify-email", r.authHandler.VerifyEmail)
+			auth.POST("/refresh", refreshRateLimit, r.authHandler.Refresh)
+
+			// Session management (require authentication)
+			auth.POST("/logout", authMiddleware, apiRateLimit, r.authHandler.Logout)
+			auth.POST("/logout-all", authMiddleware, apiRateLimit, r.authHandler.LogoutAll)
+			auth.POST("/resend-verification", authMiddleware, authRateLimit, r.authHandler.ResendVerification)
 		}
 
+		// Public certificate verification
+		v1.GET("/certificates/verify/:code", certificateVerifyRateLimit, r.certificateHandler.VerifyCertificate)
+
+		// Personal calendar feed, authenticated by the token in its URL (/calendar/<token>.ics)
+		v1.GET("/calendar/:token", calendarFeedRateLimit, r.calendarHandler.GetFeed)
+
 		// Protected routes (require authentication)
 		protected := v1.Group("")
-		protected.Use(middleware.AuthMiddleware(r.jwtSecret))
+		protected.Use(authMiddleware, apiRateLimit)
 		{
 			// User routes
-			// @Summary Get user profile
-			// @Description Get authenticated user's profile information
-			// @Tags User
-			// @Accept json
-			// @Produce json
-			// @Security BearerAuth
-			// @Success 200 {object} map[string]interface{} "Profile retrieved successfully"
-			// @Router /profile [get]
-			protected.GET("/profile", func(c *gin.Context) {
-				c.JSON(200, gin.H{
-					"success": true,
-					"message": "Profile retrieved",
-					"data": gin.H{
-						"user_id": c.GetString("userID"),
-						"email":   c.GetString("userEmail"),
-						"role":    c.GetString("userRole"),
-					},
-				})
-			})
+			profile := protected.Group("/profile")
+			{
+				profile.GET("", r.userHandler.GetProfile)
+				profile.PUT("", r.userHandler.UpdateProfile)
+				profile.POST("/password", r.userHandler.ChangePassword)
+				profile.POST("/avatar", r.userHandler.UploadAvatar)
+				profile.POST("/calendar-feed", r.calendarHandler.CreateFeed)
+				profile.DELETE("/calendar-feed", r.calendarHandler.DeleteFeed)
+			}
 
 			// Whitelist routes
 			whitelist := protected.Group("/whitelist")
@@ -114,7 +205,9 @@ func (r *Router) Setup() *gin.Engine {
 			{
 				// Public routes (anyone authenticated can view)
 				events.GET("", r.eventHandler.GetAllEvents)
+				events.GET("/search", r.eventHandler.SearchEvents)
 				events.GET("/:id", r.eventHandler.GetEvent)
+				events.GET("/:id/ical", r.calendarHandler.GetEventCalendar)
 
 				// Organisasi & Admin routes
 				events.POST("", middleware.RequireOrganisasi(), r.eventHandler.CreateEvent)
@@ -122,17 +215,31 @@ func (r *Router) Setup() *gin.Engine {
 				events.PUT("/:id", middleware.RequireOrganisasi(), r.eventHandler.UpdateEvent)
 				events.POST("/:id/poster", middleware.RequireOrganisasi(), r.eventHandler.UploadPoster)
 				events.DELETE("/:id", middleware.RequireOrganisasi(), r.eventHandler.DeleteEvent)
+				events.POST("/:id/cancel", middleware.RequireOrganisasi(), r.eventHandler.CancelEvent)
 				events.POST("/:id/publish", middleware.RequireOrganisasi(), r.eventHandler.PublishEvent)
-				events.POST("/:id/reminders", middleware.RequireOrganisasi(), r.eventHandler.SendReminders)
+				events.POST("/:id/reminders", middleware.RequireOrganisasi(), reminderRateLimit, r.eventHandler.SendReminders)
 
 				// Registration routes
-				events.POST("/:id/register", r.registrationHandler.RegisterForEvent)
+				events.POST("/:id/register", eventRegisterRateLimit, r.registrationHandler.RegisterForEvent)
 				events.GET("/:id/registrations", middleware.RequireOrganisasi(), r.registrationHandler.GetEventRegistrations)
 
 				// Attendance routes
 				events.POST("/:id/attendance", middleware.RequireOrganisasi(), r.attendanceHandler.MarkAttendance)
 				events.POST("/:id/attendance/bulk", middleware.RequireOrganisasi(), r.attendanceHandler.BulkMarkAttendance)
+				events.POST("/:id/attendance/import", middleware.RequireOrganisasi(), r.attendanceHandler.ImportAttendance)
 				events.GET("/:id/attendance", middleware.RequireOrganisasi(), r.attendanceHandler.GetEventAttendance)
+				events.GET("/:id/attendance/history", middleware.RequireOrganisasi(), r.attendanceHandler.GetAttendanceHistory)
+				events.PATCH("/:id/attendance/:userId", middleware.RequireOrganisasi(), r.attendanceHandler.UpdateAttendanceNotes)
+				events.DELETE("/:id/attendance/:userId", middleware.RequireOrganisasi(), r.attendanceHandler.RemoveAttendance)
+				events.POST("/:id/check-in", middleware.RequireOrganisasi(), r.attendanceHandler.CheckIn)
+				events.POST("/:id/check-in-window", middleware.RequireOrganisasi(), r.attendanceHandler.OpenCheckInWindow)
+				events.DELETE("/:id/check-in-window", middleware.RequireOrganisasi(), r.attendanceHandler.CloseCheckInWindow)
+				events.GET("/:id/check-in-code", middleware.RequireOrganisasi(), r.attendanceHandler.GetSelfCheckInCode)
+				events.POST("/:id/self-check-in", selfCheckInRateLimit, r.attendanceHandler.SelfCheckIn)
+
+				// Certificate routes
+				events.PUT("/:id/certificate-template", middleware.RequireOrganisasi(), r.certificateHandler.SaveTemplate)
+				events.GET("/:id/certificate-template", middleware.RequireOrganisasi(), r.certificateHandler.GetTemplate)
 			}
 
 			// Registration routes
@@ -140,6 +247,23 @@ func (r *Router) Setup() *gin.Engine {
 			{
 				registrations.GET("/my", r.registrationHandler.GetMyRegistrations)
 				registrations.DELETE("/:id", r.registrationHandler.CancelRegistration)
+				registrations.POST("/:id/claim", r.registrationHandler.ClaimOffer)
+				registrations.GET("/:id/qr", r.registrationHandler.GetCheckInQRCode)
+				registrations.GET("/:id/certificate", r.certificateHandler.DownloadCertificate)
+				registrations.POST("/:id/certificate/revoke", middleware.RequireOrganisasi(), r.certificateHandler.RevokeCertificate)
+			}
+
+			// Admin user management routes
+			admin := protected.Group("/admin", middleware.RequireAdmin())
+			{
+				admin.GET("/users", r.adminHandler.ListUsers)
+				admin.GET("/users/:id", r.adminHandler.GetUser)
+				admin.PATCH("/users/:id/role", r.adminHandler.ChangeRole)
+				admin.POST("/users/:id/suspend", r.adminHandler.SuspendUser)
+				admin.POST("/users/:id/unsuspend", r.adminHandler.UnsuspendUser)
+				admin.POST("/users/:id/revoke-organizer", r.adminHandler.RevokeOrganizer)
+				admin.POST("/users/:id/unlock", r.adminHandler.UnlockLogin)
+				admin.DELETE("/users/:id", r.adminHandler.DeleteUser)
 			}
 		}
 	}
@@ -147,5 +271,5 @@ func (r *Router) Setup() *gin.Engine {
 	// Serve uploaded files
 	router.Static("/files", "./storage")
 
-	return router
+	return router, nil
 }
@@ -6,6 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
+// Per-user outcomes of bulk attendance marking and imports
+const (
+	BulkAttendanceMarked        = "marked"
+	BulkAttendanceWillMark      = "will_mark" // dry-run import preview of marked
+	BulkAttendanceAlreadyMarked = "already_marked"
+	BulkAttendanceNotRegistered = "not_registered"
+	BulkAttendanceWaitlisted    = "waitlisted"
+	BulkAttendanceInvalidID     = "invalid_id"
+	BulkAttendanceDuplicate     = "duplicate" // listed again after its first occurrence
+)
+
 // Attendance represents attendance record for an event
 type Attendance struct {
 	ID             uuid.UUID `json:"id" db:"id"`
@@ -21,3 +32,27 @@ type Attendance struct {
 	UserEmail  *string `json:"user_email,omitempty" db:"user_email"`
 	EventTitle *string `json:"event_title,omitempty" db:"event_title"`
 }
+
+// Attendance history actions
+const (
+	AttendanceActionMarked       = "marked"
+	AttendanceActionNotesUpdated = "notes_updated"
+	AttendanceActionRemoved      = "removed"
+)
+
+// AttendanceHistory is one recorded change to the attendance of a registration
+type AttendanceHistory struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	AttendanceID   uuid.UUID `json:"attendance_id" db:"attendance_id"`
+	RegistrationID uuid.UUID `json:"registration_id" db:"registration_id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Action         string    `json:"action" db:"action"`
+	OldNotes       *string   `json:"old_notes,omitempty" db:"old_notes"`
+	NewNotes       *string   `json:"new_notes,omitempty" db:"new_notes"`
+	ChangedBy      uuid.UUID `json:"changed_by" db:"changed_by"`
+	ChangedAt      time.Time `json:"changed_at" db:"changed_at"`
+
+	// Additional fields for joined queries
+	UserName      *string `json:"user_name,omitempty" db:"user_name"`
+	ChangedByName *string `json:"changed_by_name,omitempty" db:"changed_by_name"`
+}
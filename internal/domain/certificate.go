@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Values a certificate template can place
+const (
+	CertificateFieldParticipantName  = "participant_name"
+	CertificateFieldEventTitle       = "event_title"
+	CertificateFieldEventDate        = "event_date"
+	CertificateFieldOrganizerName    = "organizer_name"
+	CertificateFieldSerialNumber     = "serial_number"
+	CertificateFieldVerificationHash = "verification_hash"
+	CertificateFieldVerificationURL  = "verification_url"
+)
+
+// Certificate field alignments relative to X
+const (
+	CertificateAlignLeft   = "left"
+	CertificateAlignCenter = "center"
+	CertificateAlignRight  = "right"
+)
+
+// Certificate page size, A4 landscape in millimetres
+const (
+	CertificatePageWidth  = 297
+	CertificatePageHeight = 210
+)
+
+// CertificateField places one value on a certificate. X and Y are millimetres from the
+// top-left corner of the page; Y is the text baseline.
+type CertificateField struct {
+	Name     string  `json:"name"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	FontSize float64 `json:"font_size"`
+	Bold     bool    `json:"bold,omitempty"`
+	Color    string  `json:"color,omitempty"` // #RRGGBB, black when empty
+	Align    string  `json:"align,omitempty"` // left, center or right, center when empty
+}
+
+// CertificateTemplate is the design of an event's certificates
+type CertificateTemplate struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	EventID        uuid.UUID          `json:"event_id" db:"event_id"`
+	BackgroundPath string             `json:"background_path" db:"background_path"`
+	Fields         []CertificateField `json:"fields" db:"fields"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// Certificate is a participation certificate issued to an attended registration
+type Certificate struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	RegistrationID   uuid.UUID  `json:"registration_id" db:"registration_id"`
+	SerialNumber     string     `json:"serial_number" db:"serial_number"`
+	ParticipantName  string     `json:"participant_name" db:"participant_name"`
+	IssuedAt         time.Time  `json:"issued_at" db:"issued_at"`
+	EmailedAt        *time.Time `json:"emailed_at,omitempty" db:"emailed_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy        *uuid.UUID `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevocationReason *string    `json:"revocation_reason,omitempty" db:"revocation_reason"`
+
+	// Additional fields for joined queries
+	EventID   uuid.UUID `json:"event_id" db:"event_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	UserEmail *string   `json:"user_email,omitempty" db:"user_email"`
+}
+
+// IsRevoked checks if the certificate was revoked by the organizer
+func (c *Certificate) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
@@ -38,6 +38,9 @@ type Event struct {
 	Category             string    `json:"category" db:"category"`
 	EventType            string    `json:"event_type" db:"event_type"`
 	Location             *string   `json:"location,omitempty" db:"location"`
+	Latitude             *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude            *float64  `json:"longitude,omitempty" db:"longitude"`
+	CheckInRadiusMeters  *int      `json:"check_in_radius_meters,omitempty" db:"check_in_radius_meters"`
 	ZoomLink             *string   `json:"zoom_link,omitempty" db:"zoom_link"`
 	PosterPath           *string   `json:"poster_path,omitempty" db:"poster_path"`
 	StartDate            time.Time `json:"start_date" db:"start_date"`
@@ -50,10 +53,29 @@ type Event struct {
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
 
+	CancellationReason *string    `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+
+	// Self check-in window opened by the organizer
+	CheckInOpensAt  *time.Time `json:"check_in_opens_at,omitempty" db:"check_in_opens_at"`
+	CheckInClosesAt *time.Time `json:"check_in_closes_at,omitempty" db:"check_in_closes_at"`
+
+	// iCalendar SEQUENCE, bumped on every update so calendar apps replace their copy
+	CalendarSequence int `json:"-" db:"calendar_sequence"`
+
 	// Additional fields for joined queries
 	OrganizerName *string `json:"organizer_name,omitempty" db:"organizer_name"`
 }
 
+// EventSearchResult is an event matched by full-text search
+type EventSearchResult struct {
+	Event
+	Rank           float64 // higher is more relevant
+	TitleHighlight string  // title with matched terms wrapped in <mark>
+	Snippet        string  // description excerpt around the matched terms
+	Fuzzy          bool    // matched by title trigram similarity only
+}
+
 // IsFull checks if event is at capacity
 func (e *Event) IsFull() bool {
 	return e.CurrentParticipants >= e.MaxParticipants
@@ -92,6 +114,20 @@ func (e *Event) HasEnded() bool {
 	return time.Now().After(e.EndDate)
 }
 
+// IsSelfCheckInOpen checks if participants can check themselves in right now
+func (e *Event) IsSelfCheckInOpen() bool {
+	if e.CheckInOpensAt == nil || e.CheckInClosesAt == nil {
+		return false
+	}
+	now := time.Now()
+	return !now.Before(*e.CheckInOpensAt) && now.Before(*e.CheckInClosesAt)
+}
+
+// HasGeofence checks if self check-in is restricted to a radius around the venue
+func (e *Event) HasGeofence() bool {
+	return e.Latitude != nil && e.Longitude != nil && e.CheckInRadiusMeters != nil
+}
+
 // AvailableSlots returns number of available slots
 func (e *Event) AvailableSlots() int {
 	return e.MaxParticipants - e.CurrentParticipants
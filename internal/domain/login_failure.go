@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Login failure scopes
+const (
+	LoginFailureScopeAccount = "account"
+	LoginFailureScopeIP      = "ip"
+)
+
+// LoginFailure tracks consecutive failed logins for an account or client IP
+type LoginFailure struct {
+	Scope         string     `json:"scope" db:"scope"`
+	Key           string     `json:"key" db:"key"`
+	FailureCount  int        `json:"failure_count" db:"failure_count"`
+	LastFailureAt time.Time  `json:"last_failure_at" db:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+}
+
+// IsLocked checks if login attempts are currently blocked
+func (f *LoginFailure) IsLocked() bool {
+	return f.LockedUntil != nil && time.Now().Before(*f.LockedUntil)
+}
+
+// RetryAfter returns how long until the next login attempt is allowed
+func (f *LoginFailure) RetryAfter() time.Duration {
+	if !f.IsLocked() {
+		return 0
+	}
+	return time.Until(*f.LockedUntil)
+}
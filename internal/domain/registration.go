@@ -10,6 +10,7 @@ import (
 const (
 	RegistrationStatusRegistered = "registered"
 	RegistrationStatusWaitlist   = "waitlist"
+	RegistrationStatusOffered    = "offered" // a freed seat is held for a waitlisted user until OfferExpiresAt
 	RegistrationStatusCancelled  = "cancelled"
 	RegistrationStatusAttended   = "attended"
 )
@@ -24,12 +25,24 @@ type Registration struct {
 	CancelledAt  *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
 	ReminderSent bool       `json:"reminder_sent" db:"reminder_sent"`
 
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty" db:"offer_expires_at"`
+
+	// CancellationReason is set when the registration was cancelled on the user's behalf
+	CancellationReason *string `json:"cancellation_reason,omitempty" db:"cancellation_reason"`
+
+	// WaitlistPosition is the 1-based place in the waitlist, set by listings for waitlisted registrations
+	WaitlistPosition *int `json:"waitlist_position,omitempty" db:"-"`
+
 	// Additional fields for joined queries
 	EventTitle *string    `json:"event_title,omitempty" db:"event_title"`
 	EventDate  *time.Time `json:"event_date,omitempty" db:"event_date"`
 	UserName   *string    `json:"user_name,omitempty" db:"user_name"`
 	UserEmail  *string    `json:"user_email,omitempty" db:"user_email"`
 	UserPhone  *string    `json:"user_phone,omitempty" db:"user_phone"`
+
+	// Attendance fields, set by exports
+	AttendedAt      *time.Time `json:"attended_at,omitempty" db:"attended_at"`
+	AttendanceNotes *string    `json:"attendance_notes,omitempty" db:"attendance_notes"`
 }
 
 // IsRegistered checks if registration is in registered status
@@ -42,6 +55,16 @@ func (r *Registration) IsWaitlist() bool {
 	return r.Status == RegistrationStatusWaitlist
 }
 
+// IsOffered checks if a seat is being offered to this registration
+func (r *Registration) IsOffered() bool {
+	return r.Status == RegistrationStatusOffered
+}
+
+// HoldsSeat checks if registration counts towards the event's participants
+func (r *Registration) HoldsSeat() bool {
+	return r.Status == RegistrationStatusRegistered || r.Status == RegistrationStatusOffered
+}
+
 // IsCancelled checks if registration is cancelled
 func (r *Registration) IsCancelled() bool {
 	return r.Status == RegistrationStatusCancelled
@@ -54,5 +77,6 @@ func (r *Registration) IsAttended() bool {
 
 // CanCancel checks if registration can be cancelled
 func (r *Registration) CanCancel() bool {
-	return r.Status == RegistrationStatusRegistered || r.Status == RegistrationStatusWaitlist
+	return r.Status == RegistrationStatusRegistered || r.Status == RegistrationStatusWaitlist ||
+		r.Status == RegistrationStatusOffered
 }
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session represents a login session backed by a rotating refresh token
+type Session struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	UserID            uuid.UUID  `json:"user_id" db:"user_id"`
+	RefreshTokenHash  string     `json:"-" db:"refresh_token_hash"`
+	PreviousTokenHash *string    `json:"-" db:"previous_token_hash"`
+	UserAgent         *string    `json:"user_agent,omitempty" db:"user_agent"`
+	IPAddress         *string    `json:"ip_address,omitempty" db:"ip_address"`
+	ExpiresAt         time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt        time.Time  `json:"last_used_at" db:"last_used_at"`
+}
+
+// IsRevoked checks if session has been revoked
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsExpired checks if session refresh token has expired
+func (s *Session) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// IsActive checks if session can still be used
+func (s *Session) IsActive() bool {
+	return !s.IsRevoked() && !s.IsExpired()
+}
@@ -16,16 +16,21 @@ const (
 
 // User represents a user in the system
 type User struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Email        string    `json:"email" db:"email"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	FullName     string    `json:"full_name" db:"full_name"`
-	PhoneNumber  string    `json:"phone_number" db:"phone_number"`
-	Role         string    `json:"role" db:"role"`
-	IsUIICivitas bool      `json:"is_uii_civitas" db:"is_uii_civitas"`
-	IsApproved   bool      `json:"is_approved" db:"is_approved"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID  `json:"id" db:"id"`
+	Email            string     `json:"email" db:"email"`
+	PasswordHash     string     `json:"-" db:"password_hash"`
+	FullName         string     `json:"full_name" db:"full_name"`
+	PhoneNumber      string     `json:"phone_number" db:"phone_number"`
+	Role             string     `json:"role" db:"role"`
+	IsUIICivitas     bool       `json:"is_uii_civitas" db:"is_uii_civitas"`
+	IsApproved       bool       `json:"is_approved" db:"is_approved"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	AvatarPath       *string    `json:"avatar_path,omitempty" db:"avatar_path"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+	SuspensionReason *string    `json:"suspension_reason,omitempty" db:"suspension_reason"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // IsUIIEmail checks if email is from UII domain
@@ -48,6 +53,26 @@ func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
 }
 
+// IsEmailVerified checks if user has confirmed ownership of their email
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsSuspended checks if user account has been suspended by an admin
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// IsDeleted checks if user account has been soft-deleted
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// IsValidRole checks if role is one of the known roles
+func IsValidRole(role string) bool {
+	return role == RoleMahasiswa || role == RoleOrganisasi || role == RoleAdmin
+}
+
 // CanCreateEvent checks if user can create events
 func (u *User) CanCreateEvent() bool {
 	return (u.Role == RoleOrganisasi && u.IsApproved) || u.Role == RoleAdmin
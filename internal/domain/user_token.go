@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User token purposes
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
+// UserToken tracks a single-use token emailed to a user
+type UserToken struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	Purpose   string     `json:"purpose" db:"purpose"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsUsed checks if token has already been consumed
+func (t *UserToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired checks if token has expired
+func (t *UserToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
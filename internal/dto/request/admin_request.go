@@ -0,0 +1,11 @@
+package request
+
+// ChangeRoleRequest represents admin role change request
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=mahasiswa organisasi admin"`
+}
+
+// SuspendUserRequest represents admin account suspension request
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
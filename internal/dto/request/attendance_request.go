@@ -6,7 +6,29 @@ type MarkAttendanceRequest struct {
 	Notes  *string `json:"notes,omitempty"`
 }
 
+// UpdateAttendanceNotesRequest represents correcting the notes of an attendance, empty clears them
+type UpdateAttendanceNotesRequest struct {
+	Notes string `json:"notes" binding:"max=1000"`
+}
+
+// CheckInRequest represents a scanned check-in QR code
+type CheckInRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 // BulkMarkAttendanceRequest represents bulk attendance marking request
 type BulkMarkAttendanceRequest struct {
-	UserIDs []string `json:"user_ids" binding:"required"`
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=1000"`
+}
+
+// OpenCheckInWindowRequest represents opening the self check-in window of an event
+type OpenCheckInWindowRequest struct {
+	DurationMinutes int `json:"duration_minutes" binding:"required,min=1,max=1440"`
+}
+
+// SelfCheckInRequest represents a participant submitting the displayed check-in code
+type SelfCheckInRequest struct {
+	Code      string   `json:"code" binding:"required,len=6,numeric"`
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
 }
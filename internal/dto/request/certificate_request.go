@@ -0,0 +1,6 @@
+package request
+
+// RevokeCertificateRequest represents revoking an issued certificate
+type RevokeCertificateRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
@@ -9,6 +9,9 @@ type CreateEventRequest struct {
 	Category             string    `json:"category" binding:"required,oneof=seminar workshop lomba konser"`
 	EventType            string    `json:"event_type" binding:"required,oneof=online offline"`
 	Location             *string   `json:"location,omitempty"`
+	Latitude             *float64  `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude            *float64  `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	CheckInRadiusMeters  *int      `json:"check_in_radius_meters,omitempty" binding:"omitempty,min=10,max=10000"`
 	ZoomLink             *string   `json:"zoom_link,omitempty"`
 	StartDate            time.Time `json:"start_date" binding:"required"`
 	EndDate              time.Time `json:"end_date" binding:"required"`
@@ -25,6 +28,9 @@ type UpdateEventRequest struct {
 	Category             *string    `json:"category,omitempty" binding:"omitempty,oneof=seminar workshop lomba konser"`
 	EventType            *string    `json:"event_type,omitempty" binding:"omitempty,oneof=online offline"`
 	Location             *string    `json:"location,omitempty"`
+	Latitude             *float64   `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude            *float64   `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	CheckInRadiusMeters  *int       `json:"check_in_radius_meters,omitempty" binding:"omitempty,min=0,max=10000"` // 0 removes the geofence
 	ZoomLink             *string    `json:"zoom_link,omitempty"`
 	StartDate            *time.Time `json:"start_date,omitempty"`
 	EndDate              *time.Time `json:"end_date,omitempty"`
@@ -34,13 +40,28 @@ type UpdateEventRequest struct {
 	Status               *string    `json:"status,omitempty" binding:"omitempty,oneof=draft published ongoing completed cancelled"`
 }
 
-// EventFilterRequest represents event filtering parameters
+// EventFilterRequest represents event filtering parameters.
+// Pagination and sorting parameters are read separately.
 type EventFilterRequest struct {
-	Category  *string    `form:"category,omitempty" binding:"omitempty,oneof=seminar workshop lomba konser"`
-	Status    *string    `form:"status,omitempty" binding:"omitempty,oneof=draft published ongoing completed cancelled"`
-	IsUIIOnly *bool      `form:"is_uii_only,omitempty"`
-	StartDate *time.Time `form:"start_date,omitempty"`
-	EndDate   *time.Time `form:"end_date,omitempty"`
-	Page      int        `form:"page,default=1" binding:"omitempty,min=1"`
-	Limit     int        `form:"limit,default=20" binding:"omitempty,min=1,max=100"`
+	Category         string     `form:"category" binding:"omitempty,oneof=seminar workshop lomba konser"`
+	Status           string     `form:"status" binding:"omitempty,oneof=draft published ongoing completed cancelled"`
+	EventType        string     `form:"event_type" binding:"omitempty,oneof=online offline"`
+	Search           string     `form:"search"`
+	OrganizerID      string     `form:"organizer_id"`
+	IsUIIOnly        *bool      `form:"is_uii_only"`
+	StartDate        *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate          *time.Time `form:"end_date" time_format:"2006-01-02"`
+	HasCapacity      bool       `form:"has_capacity"`
+	RegistrationOpen bool       `form:"registration_open"`
+}
+
+// EventSearchRequest represents full-text search parameters with the same filters as listing
+type EventSearchRequest struct {
+	Query string `form:"q" binding:"required,max=200"`
+	EventFilterRequest
+}
+
+// CancelEventRequest represents event cancellation request
+type CancelEventRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
 }
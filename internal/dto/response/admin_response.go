@@ -0,0 +1,7 @@
+package response
+
+// UserModerationResponse represents the result of suspending or deleting a user
+type UserModerationResponse struct {
+	User                   UserResponse `json:"user"`
+	CancelledRegistrations int          `json:"cancelled_registrations"`
+}
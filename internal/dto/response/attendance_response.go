@@ -36,3 +36,41 @@ func ToAttendanceResponse(att *domain.Attendance) AttendanceResponse {
 
 	return resp
 }
+
+// SelfCheckInCodeResponse represents the rotating self check-in code an organizer displays
+type SelfCheckInCodeResponse struct {
+	Code           string    `json:"code"`
+	ValidUntil     time.Time `json:"valid_until"`
+	WindowClosesAt time.Time `json:"window_closes_at"`
+}
+
+// BulkAttendanceResult is the outcome of bulk attendance marking for one requested user
+type BulkAttendanceResult struct {
+	UserID string `json:"user_id"`
+	Result string `json:"result"` // marked, already_marked, not_registered, waitlisted, invalid_id or duplicate
+}
+
+// BulkAttendanceResponse reports bulk attendance marking per user, in request order
+type BulkAttendanceResponse struct {
+	Marked  int                    `json:"marked"`
+	Summary map[string]int         `json:"summary"`
+	Results []BulkAttendanceResult `json:"results"`
+}
+
+// AttendanceImportRow is the outcome of an attendance import for one CSV row
+type AttendanceImportRow struct {
+	Line           int    `json:"line"`
+	Email          string `json:"email,omitempty"`
+	RegistrationID string `json:"registration_id,omitempty"`
+	UserName       string `json:"user_name,omitempty"`
+	Result         string `json:"result"` // will_mark (dry run), marked, already_marked, not_registered, waitlisted, invalid_id or duplicate
+	Error          string `json:"error,omitempty"`
+}
+
+// AttendanceImportResponse reports an attendance import per CSV row, in file order
+type AttendanceImportResponse struct {
+	DryRun  bool                  `json:"dry_run"`
+	Marked  int                   `json:"marked"`
+	Summary map[string]int        `json:"summary"`
+	Rows    []AttendanceImportRow `json:"rows"`
+}
@@ -9,32 +9,59 @@ import (
 
 // LoginResponse represents login response
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token            string       `json:"token"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             UserResponse `json:"user"`
 }
 
 // UserResponse represents sanitized user data
 type UserResponse struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"`
-	FullName     string    `json:"full_name"`
-	PhoneNumber  string    `json:"phone_number"`
-	Role         string    `json:"role"`
-	IsUIICivitas bool      `json:"is_uii_civitas"`
-	IsApproved   bool      `json:"is_approved"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID               uuid.UUID  `json:"id"`
+	Email            string     `json:"email"`
+	FullName         string     `json:"full_name"`
+	PhoneNumber      string     `json:"phone_number"`
+	Role             string     `json:"role"`
+	IsUIICivitas     bool       `json:"is_uii_civitas"`
+	IsApproved       bool       `json:"is_approved"`
+	EmailVerified    bool       `json:"email_verified"`
+	AvatarPath       *string    `json:"avatar_path,omitempty"`
+	AvatarURL        *string    `json:"avatar_url,omitempty"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason *string    `json:"suspension_reason,omitempty"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
 
 // ToUserResponse converts domain.User to UserResponse
 func ToUserResponse(user *domain.User) UserResponse {
 	return UserResponse{
-		ID:           user.ID,
-		Email:        user.Email,
-		FullName:     user.FullName,
-		PhoneNumber:  user.PhoneNumber,
-		Role:         user.Role,
-		IsUIICivitas: user.IsUIICivitas,
-		IsApproved:   user.IsApproved,
-		CreatedAt:    user.CreatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		FullName:         user.FullName,
+		PhoneNumber:      user.PhoneNumber,
+		Role:             user.Role,
+		IsUIICivitas:     user.IsUIICivitas,
+		IsApproved:       user.IsApproved,
+		EmailVerified:    user.IsEmailVerified(),
+		AvatarPath:       user.AvatarPath,
+		SuspendedAt:      user.SuspendedAt,
+		SuspensionReason: user.SuspensionReason,
+		DeletedAt:        user.DeletedAt,
+		CreatedAt:        user.CreatedAt,
 	}
 }
+
+// ToProfileResponse converts domain.User to UserResponse including the avatar URL
+func ToProfileResponse(user *domain.User, baseURL string) UserResponse {
+	resp := ToUserResponse(user)
+
+	// Generate avatar URL if path exists
+	if user.AvatarPath != nil && *user.AvatarPath != "" {
+		avatarURL := baseURL + "/files/" + *user.AvatarPath
+		resp.AvatarURL = &avatarURL
+	}
+
+	return resp
+}
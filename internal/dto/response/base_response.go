@@ -28,10 +28,22 @@ func ErrorResponse(message string, err string) BaseResponse {
 
 // PaginationMeta represents pagination metadata
 type PaginationMeta struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalItems int    `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginationMeta creates pagination metadata, limit must be positive
+func NewPaginationMeta(page, limit, totalItems int, nextCursor string) *PaginationMeta {
+	return &PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: totalItems,
+		TotalPages: (totalItems + limit - 1) / limit,
+		NextCursor: nextCursor,
+	}
 }
 
 // PaginatedResponse represents paginated data response
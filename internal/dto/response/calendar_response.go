@@ -0,0 +1,6 @@
+package response
+
+// CalendarFeedResponse carries the personal calendar feed URL, shown only when it is created
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+// CertificateVerificationResponse is what public verification reveals about a certificate
+type CertificateVerificationResponse struct {
+	SerialNumber     string     `json:"serial_number"`
+	Valid            bool       `json:"valid"` // not revoked, and the submitted hash matches if one was given
+	ParticipantName  string     `json:"participant_name"`
+	EventTitle       string     `json:"event_title"`
+	EventDate        time.Time  `json:"event_date"`
+	OrganizerName    string     `json:"organizer_name"`
+	IssuedAt         time.Time  `json:"issued_at"`
+	HashMatches      *bool      `json:"hash_matches,omitempty"` // only when a hash was submitted
+	Revoked          bool       `json:"revoked"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevocationReason *string    `json:"revocation_reason,omitempty"`
+}
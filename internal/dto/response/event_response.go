@@ -17,6 +17,9 @@ type EventResponse struct {
 	Category             string    `json:"category"`
 	EventType            string    `json:"event_type"`
 	Location             *string   `json:"location,omitempty"`
+	Latitude             *float64  `json:"latitude,omitempty"`
+	Longitude            *float64  `json:"longitude,omitempty"`
+	CheckInRadiusMeters  *int      `json:"check_in_radius_meters,omitempty"`
 	ZoomLink             *string   `json:"zoom_link,omitempty"`
 	PosterPath           *string   `json:"poster_path,omitempty"`
 	PosterURL            *string   `json:"poster_url,omitempty"`
@@ -31,6 +34,11 @@ type EventResponse struct {
 	IsFull               bool      `json:"is_full"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
+
+	CancellationReason *string    `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CheckInOpensAt     *time.Time `json:"check_in_opens_at,omitempty"`
+	CheckInClosesAt    *time.Time `json:"check_in_closes_at,omitempty"`
 }
 
 // EventListResponse represents list of events
@@ -38,6 +46,15 @@ type EventListResponse struct {
 	Events []EventResponse `json:"events"`
 }
 
+// EventSearchResponse represents an event search hit
+type EventSearchResponse struct {
+	EventResponse
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+	Fuzzy          bool    `json:"fuzzy"`
+}
+
 // EventRegistrantResponse represents registrant info
 type EventRegistrantResponse struct {
 	RegistrationID uuid.UUID  `json:"registration_id"`
@@ -60,6 +77,9 @@ func ToEventResponse(event *domain.Event, baseURL string) EventResponse {
 		Category:             event.Category,
 		EventType:            event.EventType,
 		Location:             event.Location,
+		Latitude:             event.Latitude,
+		Longitude:            event.Longitude,
+		CheckInRadiusMeters:  event.CheckInRadiusMeters,
 		ZoomLink:             event.ZoomLink,
 		PosterPath:           event.PosterPath,
 		StartDate:            event.StartDate,
@@ -73,6 +93,10 @@ func ToEventResponse(event *domain.Event, baseURL string) EventResponse {
 		IsFull:               event.IsFull(),
 		CreatedAt:            event.CreatedAt,
 		UpdatedAt:            event.UpdatedAt,
+		CancellationReason:   event.CancellationReason,
+		CancelledAt:          event.CancelledAt,
+		CheckInOpensAt:       event.CheckInOpensAt,
+		CheckInClosesAt:      event.CheckInClosesAt,
 	}
 
 	if event.OrganizerName != nil {
@@ -17,16 +17,19 @@ type AttendanceRepository interface {
 	GetByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*domain.Attendance, error)
 	GetByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Attendance, error)
 	Update(ctx context.Context, attendance *domain.Attendance) error
+	Delete(ctx context.Context, id uuid.UUID) error
 	BulkCreate(ctx context.Context, attendances []domain.Attendance) error
 	CountByEvent(ctx context.Context, eventID uuid.UUID) (int, error)
+	CreateHistory(ctx context.Context, entries []domain.AttendanceHistory) error
+	GetHistoryByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.AttendanceHistory, error)
 }
 
 type attendanceRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewAttendanceRepository creates a new attendance repository
-func NewAttendanceRepository(db *sql.DB) AttendanceRepository {
+func NewAttendanceRepository(db DBTX) AttendanceRepository {
 	return &attendanceRepository{
 		db: db,
 	}
@@ -42,8 +45,8 @@ func (r *attendanceRepository) Create(ctx context.Context, attendance *domain.At
 	attendance.MarkedAt = time.Now()
 
 	query := `
-		INSERT INTO attendances (id, registration_id, checked_in_at, notes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO attendances (id, registration_id, checked_in_at, notes, marked_by)
+		VALUES ($1, $2, $3, $4, $5)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -51,6 +54,7 @@ func (r *attendanceRepository) Create(ctx context.Context, attendance *domain.At
 		attendance.RegistrationID,
 		attendance.MarkedAt,
 		attendance.Notes,
+		attendance.MarkedBy,
 	)
 
 	if err != nil {
@@ -62,7 +66,7 @@ func (r *attendanceRepository) Create(ctx context.Context, attendance *domain.At
 
 func (r *attendanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Attendance, error) {
 	query := `
-		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, NULL as marked_by, a.notes
+		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, a.marked_by, a.notes
 		FROM attendances a
 		JOIN registrations r ON a.registration_id = r.id
 		WHERE a.id = $1
@@ -99,7 +103,7 @@ func (r *attendanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 
 func (r *attendanceRepository) GetByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*domain.Attendance, error) {
 	query := `
-		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, NULL as marked_by, a.notes
+		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, a.marked_by, a.notes
 		FROM attendances a
 		JOIN registrations r ON a.registration_id = r.id
 		WHERE r.event_id = $1 AND r.user_id = $2
@@ -136,7 +140,7 @@ func (r *attendanceRepository) GetByEventAndUser(ctx context.Context, eventID, u
 
 func (r *attendanceRepository) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Attendance, error) {
 	query := `
-		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, NULL as marked_by, a.notes
+		SELECT a.id, r.event_id, r.user_id, a.registration_id, a.checked_in_at, a.marked_by, a.notes
 		FROM attendances a
 		JOIN registrations r ON a.registration_id = r.id
 		WHERE r.event_id = $1
@@ -208,50 +212,55 @@ func (r *attendanceRepository) Update(ctx context.Context, attendance *domain.At
 	return nil
 }
 
-func (r *attendanceRepository) BulkCreate(ctx context.Context, attendances []domain.Attendance) error {
-	if len(attendances) == 0 {
-		return nil
+func (r *attendanceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM attendances WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attendance: %w", err)
 	}
 
-	tx, err := r.db.BeginTx(ctx, nil)
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-	defer tx.Rollback()
 
-	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO attendances (id, registration_id, checked_in_at, notes)
-		VALUES ($1, $2, $3, $4)
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
+	if rows == 0 {
+		return fmt.Errorf("attendance not found")
 	}
-	defer stmt.Close()
 
-	for _, attendance := range attendances {
-		if attendance.ID == uuid.Nil {
-			attendance.ID = uuid.New()
-		}
-		if attendance.MarkedAt.IsZero() {
-			attendance.MarkedAt = time.Now()
-		}
+	return nil
+}
 
-		_, err := stmt.ExecContext(ctx,
-			attendance.ID,
-			attendance.RegistrationID,
-			attendance.MarkedAt,
-			attendance.Notes,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to insert attendance: %w", err)
-		}
+func (r *attendanceRepository) BulkCreate(ctx context.Context, attendances []domain.Attendance) error {
+	if len(attendances) == 0 {
+		return nil
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		for _, attendance := range attendances {
+			if attendance.ID == uuid.Nil {
+				attendance.ID = uuid.New()
+			}
+			if attendance.MarkedAt.IsZero() {
+				attendance.MarkedAt = time.Now()
+			}
+
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO attendances (id, registration_id, checked_in_at, notes, marked_by)
+				VALUES ($1, $2, $3, $4, $5)
+			`,
+				attendance.ID,
+				attendance.RegistrationID,
+				attendance.MarkedAt,
+				attendance.Notes,
+				attendance.MarkedBy,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert attendance: %w", err)
+			}
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *attendanceRepository) CountByEvent(ctx context.Context, eventID uuid.UUID) (int, error) {
@@ -270,3 +279,101 @@ func (r *attendanceRepository) CountByEvent(ctx context.Context, eventID uuid.UU
 
 	return count, nil
 }
+
+func (r *attendanceRepository) CreateHistory(ctx context.Context, entries []domain.AttendanceHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		for _, entry := range entries {
+			if entry.ID == uuid.Nil {
+				entry.ID = uuid.New()
+			}
+			if entry.ChangedAt.IsZero() {
+				entry.ChangedAt = time.Now()
+			}
+
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO attendance_history (id, attendance_id, registration_id, action, old_notes, new_notes, changed_by, changed_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			`,
+				entry.ID,
+				entry.AttendanceID,
+				entry.RegistrationID,
+				entry.Action,
+				entry.OldNotes,
+				entry.NewNotes,
+				entry.ChangedBy,
+				entry.ChangedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert attendance history: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *attendanceRepository) GetHistoryByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.AttendanceHistory, error) {
+	query := `
+		SELECT h.id, h.attendance_id, h.registration_id, r.user_id, h.action, h.old_notes, h.new_notes,
+		       h.changed_by, h.changed_at, u.full_name, c.full_name
+		FROM attendance_history h
+		JOIN registrations r ON h.registration_id = r.id
+		JOIN users u ON r.user_id = u.id
+		LEFT JOIN users c ON h.changed_by = c.id
+		WHERE r.event_id = $1
+		ORDER BY h.changed_at DESC, h.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []domain.AttendanceHistory
+	for rows.Next() {
+		var entry domain.AttendanceHistory
+		var oldNotes, newNotes, changedByName sql.NullString
+		var userName string
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.AttendanceID,
+			&entry.RegistrationID,
+			&entry.UserID,
+			&entry.Action,
+			&oldNotes,
+			&newNotes,
+			&entry.ChangedBy,
+			&entry.ChangedAt,
+			&userName,
+			&changedByName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attendance history: %w", err)
+		}
+
+		entry.UserName = &userName
+		if oldNotes.Valid {
+			entry.OldNotes = &oldNotes.String
+		}
+		if newNotes.Valid {
+			entry.NewNotes = &newNotes.String
+		}
+		if changedByName.Valid {
+			entry.ChangedByName = &changedByName.String
+		}
+
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attendance history: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"event-campus-backend/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CertificateRepository defines interface for certificate and certificate template data access
+type CertificateRepository interface {
+	SaveTemplate(ctx context.Context, template *domain.CertificateTemplate) error
+	GetTemplateByEvent(ctx context.Context, eventID uuid.UUID) (*domain.CertificateTemplate, error)
+	Create(ctx context.Context, certificate *domain.Certificate) (bool, error)
+	GetByRegistration(ctx context.Context, registrationID uuid.UUID) (*domain.Certificate, error)
+	GetBySerial(ctx context.Context, serialNumber string) (*domain.Certificate, error)
+	GetPendingRegistrations(ctx context.Context, limit int) ([]domain.Registration, error)
+	GetNotEmailed(ctx context.Context, limit int) ([]domain.Certificate, error)
+	MarkEmailed(ctx context.Context, id uuid.UUID) error
+	Revoke(ctx context.Context, id, revokedBy uuid.UUID, reason string) (bool, error)
+	RevokeByRegistration(ctx context.Context, registrationID, revokedBy uuid.UUID, reason string) (bool, error)
+}
+
+// certificateColumns lists the certificates columns in the order scanCertificate expects
+// them, selected FROM certificates c JOIN registrations r JOIN users u
+const certificateColumns = `c.id, c.registration_id, c.serial_number, c.participant_name, c.issued_at, c.emailed_at,
+		       r.event_id, r.user_id, u.email`
+
+type certificateRepository struct {
+	db DBTX
+}
+
+// NewCertificateRepository creates a new certificate repository
+func NewCertificateRepository(db DBTX) CertificateRepository {
+	return &certificateRepository{
+		db: db,
+	}
+}
+
+// SaveTemplate creates the template of an event or replaces its background and fields
+func (r *certificateRepository) SaveTemplate(ctx context.Context, template *domain.CertificateTemplate) error {
+	if template.ID == uuid.Nil {
+		template.ID = uuid.New()
+	}
+
+	fields, err := json.Marshal(template.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode certificate fields: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO certificate_templates (id, event_id, background_path, fields, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (event_id) DO UPDATE
+		SET background_path = EXCLUDED.background_path, fields = EXCLUDED.fields, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`
+
+	err = r.db.QueryRowContext(ctx, query,
+		template.ID,
+		template.EventID,
+		template.BackgroundPath,
+		fields,
+		now,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save certificate template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *certificateRepository) GetTemplateByEvent(ctx context.Context, eventID uuid.UUID) (*domain.CertificateTemplate, error) {
+	query := `
+		SELECT id, event_id, background_path, fields, created_at, updated_at
+		FROM certificate_templates
+		WHERE event_id = $1
+	`
+
+	var template domain.CertificateTemplate
+	var fields []byte
+
+	err := r.db.QueryRowContext(ctx, query, eventID).Scan(
+		&template.ID,
+		&template.EventID,
+		&template.BackgroundPath,
+		&fields,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate template: %w", err)
+	}
+
+	if err := json.Unmarshal(fields, &template.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode certificate fields: %w", err)
+	}
+
+	return &template, nil
+}
+
+// Create issues a certificate. Returns false when the registration already has one.
+func (r *certificateRepository) Create(ctx context.Context, certificate *domain.Certificate) (bool, error) {
+	if certificate.ID == uuid.Nil {
+		certificate.ID = uuid.New()
+	}
+	certificate.IssuedAt = time.Now()
+
+	query := `
+		INSERT INTO certificates (id, registration_id, serial_number, participant_name, issued_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (registration_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		certificate.ID,
+		certificate.RegistrationID,
+		certificate.SerialNumber,
+		certificate.ParticipantName,
+		certificate.IssuedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *certificateRepository) GetByRegistration(ctx context.Context, registrationID uuid.UUID) (*domain.Certificate, error) {
+	query := "SELECT " + certificateColumns + `
+		FROM certificates c
+		JOIN registrations r ON c.registration_id = r.id
+		JOIN users u ON r.user_id = u.id
+		WHERE c.registration_id = $1
+	`
+
+	certificate, err := scanCertificate(r.db.QueryRowContext(ctx, query, registrationID))
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	return certificate, nil
+}
+
+func (r *certificateRepository) GetBySerial(ctx context.Context, serialNumber string) (*domain.Certificate, error) {
+	query := "SELECT " + certificateColumns + `
+		FROM certificates c
+		JOIN registrations r ON c.registration_id = r.id
+		JOIN users u ON r.user_id = u.id
+		WHERE c.serial_number = $1
+	`
+
+	certificate, err := scanCertificate(r.db.QueryRowContext(ctx, query, serialNumber))
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	return certificate, nil
+}
+
+// GetPendingRegistrations lists attended registrations of completed events with a
+// certificate template that have no certificate yet, with the user's name
+func (r *certificateRepository) GetPendingRegistrations(ctx context.Context, limit int) ([]domain.Registration, error) {
+	query := "SELECT " + registrationColumns + `, u.full_name
+		FROM registrations r
+		JOIN events e ON r.event_id = e.id
+		JOIN users u ON r.user_id = u.id
+		JOIN certificate_templates t ON t.event_id = e.id
+		WHERE e.status = $1 AND r.status = $2
+		  AND NOT EXISTS (SELECT 1 FROM certificates c WHERE c.registration_id = r.id)
+		ORDER BY e.end_date ASC, r.registered_at ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.StatusCompleted, domain.RegistrationStatusAttended, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var registrations []domain.Registration
+	for rows.Next() {
+		var userName string
+		registration, err := scanRegistration(rows, &userName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registration: %w", err)
+		}
+		registration.UserName = &userName
+
+		registrations = append(registrations, *registration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate registrations: %w", err)
+	}
+
+	return registrations, nil
+}
+
+// GetNotEmailed lists issued certificates that have not been emailed yet and are not
+// revoked, oldest first
+func (r *certificateRepository) GetNotEmailed(ctx context.Context, limit int) ([]domain.Certificate, error) {
+	query := "SELECT " + certificateColumns + `
+		FROM certificates c
+		JOIN registrations r ON c.registration_id = r.id
+		JOIN users u ON r.user_id = u.id
+		WHERE c.emailed_at IS NULL AND c.revoked_at IS NULL
+		ORDER BY c.issued_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certificates []domain.Certificate
+	for rows.Next() {
+		certificate, err := scanCertificate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan certificate: %w", err)
+		}
+
+		certificates = append(certificates, *certificate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate certificates: %w", err)
+	}
+
+	return certificates, nil
+}
+
+func (r *certificateRepository) MarkEmailed(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE certificates SET emailed_at = $1 WHERE id = $2`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark certificate emailed: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke marks a certificate revoked. Returns false when it already was.
+func (r *certificateRepository) Revoke(ctx context.Context, id, revokedBy uuid.UUID, reason string) (bool, error) {
+	query := `
+		UPDATE certificates
+		SET revoked_at = $1, revoked_by = $2, revocation_reason = $3
+		WHERE id = $4 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), revokedBy, reason, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke certificate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// RevokeByRegistration revokes the certificate of a registration. Returns false when
+// none was issued or it already was revoked.
+func (r *certificateRepository) RevokeByRegistration(ctx context.Context, registrationID, revokedBy uuid.UUID, reason string) (bool, error) {
+	query := `
+		UPDATE certificates
+		SET revoked_at = $1, revoked_by = $2, revocation_reason = $3
+		WHERE registration_id = $4 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), revokedBy, reason, registrationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke certificate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanCertificate scans a row of certificateColumns
+func scanCertificate(row rowScanner) (*domain.Certificate, error) {
+	var certificate domain.Certificate
+	var emailedAt, revokedAt sql.NullTime
+	var revokedBy uuid.NullUUID
+	var revocationReason sql.NullString
+	var userEmail string
+
+	err := row.Scan(
+		&certificate.ID,
+		&certificate.RegistrationID,
+		&certificate.SerialNumber,
+		&certificate.ParticipantName,
+		&certificate.IssuedAt,
+		&emailedAt,
+		&revokedAt,
+		&revokedBy,
+		&revocationReason,
+		&certificate.EventID,
+		&certificate.UserID,
+		&userEmail,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailedAt.Valid {
+		certificate.EmailedAt = &emailedAt.Time
+	}
+	if revokedAt.Valid {
+		certificate.RevokedAt = &revokedAt.Time
+	}
+	if revokedBy.Valid {
+		certificate.RevokedBy = &revokedBy.UUID
+	}
+	if revocationReason.Valid {
+		certificate.RevocationReason = &revocationReason.String
+	}
+	certificate.UserEmail = &userEmail
+
+	return &certificate, nil
+}
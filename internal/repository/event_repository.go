@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"event-campus-backend/internal/domain"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,21 +15,95 @@ import (
 type EventRepository interface {
 	Create(ctx context.Context, event *domain.Event) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Event, error)
-	GetAll(ctx context.Context, filters map[string]interface{}) ([]domain.Event, error)
-	GetByOrganizer(ctx context.Context, organizerID uuid.UUID) ([]domain.Event, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Event, error)
+	GetAll(ctx context.Context, filter EventFilter, opts ListOptions) ([]domain.Event, *PageInfo, error)
+	Search(ctx context.Context, query string, filter EventFilter, opts ListOptions) ([]domain.EventSearchResult, *PageInfo, error)
+	GetByOrganizer(ctx context.Context, organizerID uuid.UUID, opts ListOptions) ([]domain.Event, *PageInfo, error)
+	GetByParticipant(ctx context.Context, userID uuid.UUID, endedAfter time.Time) ([]domain.Event, error)
 	Update(ctx context.Context, event *domain.Event) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
 	IncrementParticipants(ctx context.Context, id uuid.UUID) error
 	DecrementParticipants(ctx context.Context, id uuid.UUID) error
+	Cancel(ctx context.Context, id uuid.UUID, reason string) error
+	SetCheckInWindow(ctx context.Context, id uuid.UUID, opensAt, closesAt *time.Time) error
 }
 
+// EventFilter holds event listing filters, zero values are ignored
+type EventFilter struct {
+	Category         string
+	Status           string
+	EventType        string
+	Search           string // full-text match on title and description, or typo-tolerant match on title
+	OrganizerID      *uuid.UUID
+	IsUIIOnly        *bool
+	From             *time.Time // events still running at or after From
+	To               *time.Time // events starting before To
+	HasCapacity      bool       // events with seats left
+	RegistrationOpen bool       // published events whose registration deadline has not passed
+}
+
+// Event sort keys accepted by GetAll and GetByOrganizer
+const (
+	EventSortStartDate  = "start_date"
+	EventSortDeadline   = "deadline"
+	EventSortPopularity = "popularity"
+	EventSortCreated    = "created"
+)
+
+// eventSorts returns the event sort keys with the given default, newest first
+func eventSorts(defaultSort string) sortSpec {
+	return sortSpec{
+		columns: map[string]sortColumn{
+			EventSortStartDate:  {column: "events.start_date", cast: "timestamp"},
+			EventSortDeadline:   {column: "events.registration_deadline", cast: "timestamp"},
+			EventSortPopularity: {column: "events.current_participants", cast: "int"},
+			EventSortCreated:    {column: "events.created_at", cast: "timestamp"},
+		},
+		idColumn:     "events.id",
+		defaultSort:  defaultSort,
+		defaultOrder: SortDesc,
+	}
+}
+
+// eventSortValue returns the value of the sort column of an event, as stored in cursors
+func eventSortValue(event *domain.Event, sort string) string {
+	switch sort {
+	case EventSortDeadline:
+		return event.RegistrationDeadline.Format(time.RFC3339Nano)
+	case EventSortPopularity:
+		return strconv.Itoa(event.CurrentParticipants)
+	case EventSortCreated:
+		return event.CreatedAt.Format(time.RFC3339Nano)
+	default:
+		return event.StartDate.Format(time.RFC3339Nano)
+	}
+}
+
+// eventColumns lists the events columns in the order scanEvent expects them
+const eventColumns = `events.id, events.organizer_id, events.title, events.description,
+		       events.category, events.event_type, events.location, events.zoom_link,
+		       events.poster_path, events.start_date, events.end_date,
+		       events.registration_deadline, events.max_participants,
+		       events.current_participants, events.is_uii_only, events.status,
+		       events.created_at, events.updated_at, events.cancellation_reason,
+		       events.cancelled_at, events.check_in_opens_at, events.check_in_closes_at,
+		       events.latitude, events.longitude, events.check_in_radius_meters,
+		       events.calendar_sequence`
+
+// eventsWithOrganizer joins each event to its organizer so that listings
+// carry the organizer name without a lookup per event
+const eventsWithOrganizer = "events LEFT JOIN users o ON o.id = events.organizer_id"
+
+// eventWithOrganizerColumns are selected FROM eventsWithOrganizer and scanned by scanEventWithOrganizer
+const eventWithOrganizerColumns = eventColumns + ", o.full_name"
+
 type eventRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewEventRepository creates a new event repository
-func NewEventRepository(db *sql.DB) EventRepository {
+func NewEventRepository(db DBTX) EventRepository {
 	return &eventRepository{
 		db: db,
 	}
@@ -58,8 +133,9 @@ func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error
 			id, organizer_id, title, description, category, event_type,
 			location, zoom_link, poster_path, start_date, end_date,
 			registration_deadline, max_participants, current_participants,
-			is_uii_only, status, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			is_uii_only, status, created_at, updated_at, latitude, longitude,
+			check_in_radius_meters
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -81,6 +157,9 @@ func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error
 		event.Status,
 		event.CreatedAt,
 		event.UpdatedAt,
+		event.Latitude,
+		event.Longitude,
+		event.CheckInRadiusMeters,
 	)
 
 	if err != nil {
@@ -91,39 +170,9 @@ func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error
 }
 
 func (r *eventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Event, error) {
-	query := `
-		SELECT id, organizer_id, title, description, category, event_type,
-		       location, zoom_link, poster_path, start_date, end_date,
-		       registration_deadline, max_participants, current_participants,
-		       is_uii_only, status, created_at, updated_at
-		FROM events
-		WHERE id = $1
-	`
-
-	var event domain.Event
-	var location, zoomLink, posterPath sql.NullString
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&event.ID,
-		&event.OrganizerID,
-		&event.Title,
-		&event.Description,
-		&event.Category,
-		&event.EventType,
-		&location,
-		&zoomLink,
-		&posterPath,
-		&event.StartDate,
-		&event.EndDate,
-		&event.RegistrationDeadline,
-		&event.MaxParticipants,
-		&event.CurrentParticipants,
-		&event.IsUIIOnly,
-		&event.Status,
-		&event.CreatedAt,
-		&event.UpdatedAt,
-	)
+	query := "SELECT " + eventWithOrganizerColumns + " FROM " + eventsWithOrganizer + " WHERE events.id = $1"
 
+	event, err := scanEventWithOrganizer(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("event not found")
 	}
@@ -132,129 +181,214 @@ func (r *eventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ev
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	if location.Valid {
-		s := location.String
-		event.Location = &s
-	}
-	if zoomLink.Valid {
-		s := zoomLink.String
-		event.ZoomLink = &s
+	return event, nil
+}
+
+// GetByIDForUpdate gets an event and locks its row until the surrounding transaction
+// ends, serializing seat changes with CreateWithSeat. Organizer name is not joined.
+func (r *eventRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Event, error) {
+	query := "SELECT " + eventColumns + " FROM events WHERE events.id = $1 FOR UPDATE"
+
+	event, err := scanEvent(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("event not found")
 	}
-	if posterPath.Valid {
-		s := posterPath.String
-		event.PosterPath = &s
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	return &event, nil
+	return event, nil
 }
 
-func (r *eventRepository) GetAll(ctx context.Context, filters map[string]interface{}) ([]domain.Event, error) {
-	query := `
-		SELECT id, organizer_id, title, description, category, event_type,
-		       location, zoom_link, poster_path, start_date, end_date,
-		       registration_deadline, max_participants, current_participants,
-		       is_uii_only, status, created_at, updated_at
-		FROM events
-		WHERE 1=1
-	`
+func (r *eventRepository) GetAll(ctx context.Context, filter EventFilter, opts ListOptions) ([]domain.Event, *PageInfo, error) {
+	where, args := filter.where()
+	return r.list(ctx, where, args, opts, eventSorts(EventSortStartDate))
+}
 
+// where builds the WHERE clause of the filter, numbering arguments from $1
+func (filter EventFilter) where() (string, []interface{}) {
+	where := " WHERE 1=1"
 	var args []interface{}
 	argCount := 1
 
 	// Apply filters
-	if category, ok := filters["category"].(string); ok && category != "" {
-		query += fmt.Sprintf(" AND category = $%d", argCount)
-		args = append(args, category)
+	if filter.Category != "" {
+		where += fmt.Sprintf(" AND category = $%d", argCount)
+		args = append(args, filter.Category)
+		argCount++
+	}
+
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND status = $%d", argCount)
+		args = append(args, filter.Status)
 		argCount++
 	}
 
-	if status, ok := filters["status"].(string); ok && status != "" {
-		query += fmt.Sprintf(" AND status = $%d", argCount)
-		args = append(args, status)
+	if filter.EventType != "" {
+		where += fmt.Sprintf(" AND event_type = $%d", argCount)
+		args = append(args, filter.EventType)
 		argCount++
 	}
 
-	if eventType, ok := filters["event_type"].(string); ok && eventType != "" {
-		query += fmt.Sprintf(" AND event_type = $%d", argCount)
-		args = append(args, eventType)
+	if filter.Search != "" {
+		where += fmt.Sprintf(" AND (search_vector @@ %s OR $%d <%% title)", eventSearchQuery(argCount), argCount)
+		args = append(args, filter.Search)
 		argCount++
 	}
 
-	if search, ok := filters["search"].(string); ok && search != "" {
-		query += fmt.Sprintf(" AND (title ILIKE $%d OR description ILIKE $%d)", argCount, argCount)
-		args = append(args, "%"+search+"%")
+	if filter.OrganizerID != nil {
+		where += fmt.Sprintf(" AND organizer_id = $%d", argCount)
+		args = append(args, *filter.OrganizerID)
 		argCount++
 	}
 
-	query += " ORDER BY start_date DESC"
+	if filter.IsUIIOnly != nil {
+		where += fmt.Sprintf(" AND is_uii_only = $%d", argCount)
+		args = append(args, *filter.IsUIIOnly)
+		argCount++
+	}
+
+	// Events overlapping the window [From, To)
+	if filter.From != nil {
+		where += fmt.Sprintf(" AND end_date >= $%d", argCount)
+		args = append(args, *filter.From)
+		argCount++
+	}
+
+	if filter.To != nil {
+		where += fmt.Sprintf(" AND start_date < $%d", argCount)
+		args = append(args, *filter.To)
+		argCount++
+	}
+
+	if filter.HasCapacity {
+		where += " AND current_participants < max_participants"
+	}
+
+	// Same rule as domain.Event.CanRegister
+	if filter.RegistrationOpen {
+		where += fmt.Sprintf(" AND status = '%s' AND registration_deadline > $%d AND start_date > $%d",
+			domain.StatusPublished, argCount, argCount)
+		args = append(args, time.Now())
+	}
+
+	return where, args
+}
+
+// eventSearchQuery returns the tsquery matching search terms in argument $n
+// against both the Indonesian and English lexemes of search_vector
+func eventSearchQuery(n int) string {
+	return fmt.Sprintf("(websearch_to_tsquery('indonesian', $%d) || websearch_to_tsquery('english', $%d))", n, n)
+}
+
+// Search ranks events matching query by full-text relevance. When nothing
+// matches, it falls back to trigram similarity on the title so that typos
+// still find the event. Only page/limit pagination is supported.
+func (r *eventRepository) Search(ctx context.Context, query string, filter EventFilter, opts ListOptions) ([]domain.EventSearchResult, *PageInfo, error) {
+	if opts.Cursor != "" {
+		return nil, nil, fmt.Errorf("%w: cursor is not supported for search", ErrInvalidListOptions)
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+
+	filter.Search = ""
+	where, args := filter.where()
+	n := len(args) + 1
+	args = append(args, query)
+
+	// Full-text match, ranked with cover density
+	ftsWhere := where + fmt.Sprintf(" AND search_vector @@ %s", eventSearchQuery(n))
+	ftsSelect := fmt.Sprintf(`SELECT %s,
+		       ts_rank_cd(search_vector, %[2]s) AS rank,
+		       ts_headline('indonesian', title, %[2]s, '%[3]s, HighlightAll=true'),
+		       ts_headline('indonesian', description, %[2]s, '%[3]s, MaxFragments=2, MaxWords=30, MinWords=10'),
+		       FALSE
+		FROM `+eventsWithOrganizer, eventWithOrganizerColumns, eventSearchQuery(n), searchHighlightOptions)
+
+	results, page, err := r.search(ctx, ftsSelect, ftsWhere, args, opts)
+	if err != nil || page.TotalItems > 0 {
+		return results, page, err
+	}
+
+	// Typo-tolerant fallback on title word similarity
+	fuzzyWhere := where + fmt.Sprintf(" AND $%d <%% title", n)
+	fuzzySelect := fmt.Sprintf(`SELECT %s,
+		       word_similarity($%d, title) AS rank,
+		       title,
+		       LEFT(description, %d),
+		       TRUE
+		FROM `+eventsWithOrganizer, eventWithOrganizerColumns, n, searchSnippetLength)
+
+	return r.search(ctx, fuzzySelect, fuzzyWhere, args, opts)
+}
+
+const (
+	// searchHighlightOptions marks matched terms in ts_headline output
+	searchHighlightOptions = "StartSel=<mark>, StopSel=</mark>"
+
+	// searchSnippetLength is the description prefix returned for fuzzy matches
+	searchSnippetLength = 200
+)
+
+// search runs one ranked search query, selectClause must end with the rank,
+// title highlight, snippet and fuzzy columns after eventWithOrganizerColumns
+func (r *eventRepository) search(ctx context.Context, selectClause, where string, args []interface{}, opts ListOptions) ([]domain.EventSearchResult, *PageInfo, error) {
+	page := &PageInfo{}
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM events"+where, args...).Scan(&page.TotalItems); err != nil {
+		return nil, nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	if page.TotalItems == 0 {
+		return nil, page, nil
+	}
+
+	// Rank ties go to the event starting first
+	query := selectClause + where + " ORDER BY rank DESC, events.start_date ASC, events.id ASC"
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+		args = append(args, opts.Limit, (opts.Page-1)*opts.Limit)
+	}
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get events: %w", err)
+		return nil, nil, fmt.Errorf("failed to search events: %w", err)
 	}
 	defer rows.Close()
 
-	var events []domain.Event
+	var results []domain.EventSearchResult
 	for rows.Next() {
-		var event domain.Event
-		var location, zoomLink, posterPath sql.NullString
-
-		err := rows.Scan(
-			&event.ID,
-			&event.OrganizerID,
-			&event.Title,
-			&event.Description,
-			&event.Category,
-			&event.EventType,
-			&location,
-			&zoomLink,
-			&posterPath,
-			&event.StartDate,
-			&event.EndDate,
-			&event.RegistrationDeadline,
-			&event.MaxParticipants,
-			&event.CurrentParticipants,
-			&event.IsUIIOnly,
-			&event.Status,
-			&event.CreatedAt,
-			&event.UpdatedAt,
-		)
-
+		var result domain.EventSearchResult
+		event, err := scanEventWithOrganizer(rows, &result.Rank, &result.TitleHighlight, &result.Snippet, &result.Fuzzy)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan event: %w", err)
-		}
-
-		if location.Valid {
-			s := location.String
-			event.Location = &s
-		}
-		if zoomLink.Valid {
-			s := zoomLink.String
-			event.ZoomLink = &s
+			return nil, nil, fmt.Errorf("failed to scan event: %w", err)
 		}
-		if posterPath.Valid {
-			s := posterPath.String
-			event.PosterPath = &s
-		}
-
-		events = append(events, event)
+		result.Event = *event
+		results = append(results, result)
 	}
 
-	return events, nil
+	return results, page, nil
 }
 
-func (r *eventRepository) GetByOrganizer(ctx context.Context, organizerID uuid.UUID) ([]domain.Event, error) {
-	query := `
-		SELECT id, organizer_id, title, description, category, event_type,
-		       location, zoom_link, poster_path, start_date, end_date,
-		       registration_deadline, max_participants, current_participants,
-		       is_uii_only, status, created_at, updated_at
-		FROM events
-		WHERE organizer_id = $1
-		ORDER BY created_at DESC
+func (r *eventRepository) GetByOrganizer(ctx context.Context, organizerID uuid.UUID, opts ListOptions) ([]domain.Event, *PageInfo, error) {
+	return r.list(ctx, " WHERE organizer_id = $1", []interface{}{organizerID}, opts, eventSorts(EventSortCreated))
+}
+
+// GetByParticipant lists the events the user is registered for or attended that ended
+// after endedAfter, soonest first
+func (r *eventRepository) GetByParticipant(ctx context.Context, userID uuid.UUID, endedAfter time.Time) ([]domain.Event, error) {
+	query := "SELECT " + eventWithOrganizerColumns + " FROM " + eventsWithOrganizer + `
+		JOIN registrations r ON r.event_id = events.id
+		WHERE r.user_id = $1 AND r.status IN ($2, $3) AND events.end_date > $4
+		ORDER BY events.start_date ASC, events.id ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, organizerID)
+	rows, err := r.db.QueryContext(ctx, query,
+		userID,
+		domain.RegistrationStatusRegistered,
+		domain.RegistrationStatusAttended,
+		endedAfter,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
@@ -262,53 +396,64 @@ func (r *eventRepository) GetByOrganizer(ctx context.Context, organizerID uuid.U
 
 	var events []domain.Event
 	for rows.Next() {
-		var event domain.Event
-		var location, zoomLink, posterPath sql.NullString
-
-		err := rows.Scan(
-			&event.ID,
-			&event.OrganizerID,
-			&event.Title,
-			&event.Description,
-			&event.Category,
-			&event.EventType,
-			&location,
-			&zoomLink,
-			&posterPath,
-			&event.StartDate,
-			&event.EndDate,
-			&event.RegistrationDeadline,
-			&event.MaxParticipants,
-			&event.CurrentParticipants,
-			&event.IsUIIOnly,
-			&event.Status,
-			&event.CreatedAt,
-			&event.UpdatedAt,
-		)
-
+		event, err := scanEventWithOrganizer(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
 		}
 
-		if location.Valid {
-			s := location.String
-			event.Location = &s
-		}
-		if zoomLink.Valid {
-			s := zoomLink.String
-			event.ZoomLink = &s
+		events = append(events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+
+	return events, nil
+}
+
+// list runs a paginated event query with the given WHERE clause
+func (r *eventRepository) list(ctx context.Context, where string, args []interface{}, opts ListOptions, sorts sortSpec) ([]domain.Event, *PageInfo, error) {
+	opts, err := sorts.resolve(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &PageInfo{}
+	if opts.Limit > 0 {
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM events"+where, args...).Scan(&page.TotalItems); err != nil {
+			return nil, nil, fmt.Errorf("failed to count events: %w", err)
 		}
-		if posterPath.Valid {
-			s := posterPath.String
-			event.PosterPath = &s
+	}
+
+	query, args := sorts.paginate("SELECT "+eventWithOrganizerColumns+" FROM "+eventsWithOrganizer+where, args, opts)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.Event
+	for rows.Next() {
+		event, err := scanEventWithOrganizer(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan event: %w", err)
 		}
+		events = append(events, *event)
+	}
 
-		events = append(events, event)
+	if opts.Limit == 0 {
+		page.TotalItems = len(events)
+	} else if len(events) > opts.Limit {
+		events = events[:opts.Limit]
+		last := events[len(events)-1]
+		page.NextCursor = opts.nextCursor(eventSortValue(&last, opts.Sort), last.ID)
 	}
 
-	return events, nil
+	return events, page, nil
 }
 
+// Update saves the event and bumps its calendar sequence
 func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error {
 	event.UpdatedAt = time.Now()
 
@@ -318,11 +463,13 @@ func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error
 		    location = $5, zoom_link = $6, poster_path = $7,
 		    start_date = $8, end_date = $9, registration_deadline = $10,
 		    max_participants = $11, is_uii_only = $12, status = $13,
-		    updated_at = $14
-		WHERE id = $15
+		    updated_at = $14, latitude = $15, longitude = $16,
+		    check_in_radius_meters = $17, calendar_sequence = calendar_sequence + 1
+		WHERE id = $18
+		RETURNING calendar_sequence
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	err := r.db.QueryRowContext(ctx, query,
 		event.Title,
 		event.Description,
 		event.Category,
@@ -337,20 +484,18 @@ func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error
 		event.IsUIIOnly,
 		event.Status,
 		event.UpdatedAt,
+		event.Latitude,
+		event.Longitude,
+		event.CheckInRadiusMeters,
 		event.ID,
-	)
+	).Scan(&event.CalendarSequence)
 
-	if err != nil {
-		return fmt.Errorf("failed to update event: %w", err)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("event not found")
 	}
 
-	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("event not found")
+		return fmt.Errorf("failed to update event: %w", err)
 	}
 
 	return nil
@@ -447,3 +592,151 @@ func (r *eventRepository) DecrementParticipants(ctx context.Context, id uuid.UUI
 
 	return nil
 }
+
+// Cancel marks the event cancelled with the organizer's reason. Its seats are
+// released, the registrations are cancelled separately.
+func (r *eventRepository) Cancel(ctx context.Context, id uuid.UUID, reason string) error {
+	now := time.Now()
+
+	query := `
+		UPDATE events
+		SET status = $1, cancellation_reason = $2, cancelled_at = $3,
+		    current_participants = 0, updated_at = $3, calendar_sequence = calendar_sequence + 1
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, domain.StatusCancelled, reason, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel event: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+// SetCheckInWindow opens the self check-in window of the event, or closes it when both times are nil
+func (r *eventRepository) SetCheckInWindow(ctx context.Context, id uuid.UUID, opensAt, closesAt *time.Time) error {
+	query := `
+		UPDATE events
+		SET check_in_opens_at = $1, check_in_closes_at = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, opensAt, closesAt, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update check-in window: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+// scanEvent scans a row selected with eventColumns, followed by any extra columns
+func scanEvent(row rowScanner, extra ...interface{}) (*domain.Event, error) {
+	var event domain.Event
+	var location, zoomLink, posterPath, cancellationReason sql.NullString
+	var cancelledAt, checkInOpensAt, checkInClosesAt sql.NullTime
+	var latitude, longitude sql.NullFloat64
+	var checkInRadius sql.NullInt64
+
+	dest := []interface{}{
+		&event.ID,
+		&event.OrganizerID,
+		&event.Title,
+		&event.Description,
+		&event.Category,
+		&event.EventType,
+		&location,
+		&zoomLink,
+		&posterPath,
+		&event.StartDate,
+		&event.EndDate,
+		&event.RegistrationDeadline,
+		&event.MaxParticipants,
+		&event.CurrentParticipants,
+		&event.IsUIIOnly,
+		&event.Status,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+		&cancellationReason,
+		&cancelledAt,
+		&checkInOpensAt,
+		&checkInClosesAt,
+		&latitude,
+		&longitude,
+		&checkInRadius,
+		&event.CalendarSequence,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if location.Valid {
+		s := location.String
+		event.Location = &s
+	}
+	if zoomLink.Valid {
+		s := zoomLink.String
+		event.ZoomLink = &s
+	}
+	if posterPath.Valid {
+		s := posterPath.String
+		event.PosterPath = &s
+	}
+	if cancellationReason.Valid {
+		s := cancellationReason.String
+		event.CancellationReason = &s
+	}
+	if cancelledAt.Valid {
+		event.CancelledAt = &cancelledAt.Time
+	}
+	if checkInOpensAt.Valid {
+		event.CheckInOpensAt = &checkInOpensAt.Time
+	}
+	if checkInClosesAt.Valid {
+		event.CheckInClosesAt = &checkInClosesAt.Time
+	}
+	if latitude.Valid && longitude.Valid {
+		event.Latitude = &latitude.Float64
+		event.Longitude = &longitude.Float64
+	}
+	if checkInRadius.Valid {
+		radius := int(checkInRadius.Int64)
+		event.CheckInRadiusMeters = &radius
+	}
+
+	return &event, nil
+}
+
+// scanEventWithOrganizer scans a row selected with eventWithOrganizerColumns, followed by any extra columns
+func scanEventWithOrganizer(row rowScanner, extra ...interface{}) (*domain.Event, error) {
+	var organizerName sql.NullString
+
+	event, err := scanEvent(row, append([]interface{}{&organizerName}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizerName.Valid {
+		s := organizerName.String
+		event.OrganizerName = &s
+	}
+
+	return event, nil
+}
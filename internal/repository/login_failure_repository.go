@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"fmt"
+	"time"
+)
+
+// LoginFailureRepository defines interface for failed login tracking
+type LoginFailureRepository interface {
+	Get(ctx context.Context, scope, key string) (*domain.LoginFailure, error)
+	RecordFailure(ctx context.Context, scope, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, scope, key string, until time.Time) error
+	Reset(ctx context.Context, scope, key string) error
+}
+
+type loginFailureRepository struct {
+	db DBTX
+}
+
+// NewLoginFailureRepository creates a new login failure repository
+func NewLoginFailureRepository(db DBTX) LoginFailureRepository {
+	return &loginFailureRepository{
+		db: db,
+	}
+}
+
+func (r *loginFailureRepository) Get(ctx context.Context, scope, key string) (*domain.LoginFailure, error) {
+	query := `
+		SELECT scope, key, failure_count, last_failure_at, locked_until
+		FROM login_failures
+		WHERE scope = $1 AND key = $2
+	`
+
+	var failure domain.LoginFailure
+	var lockedUntil sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, scope, key).Scan(
+		&failure.Scope,
+		&failure.Key,
+		&failure.FailureCount,
+		&failure.LastFailureAt,
+		&lockedUntil,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login failures: %w", err)
+	}
+
+	if lockedUntil.Valid {
+		failure.LockedUntil = &lockedUntil.Time
+	}
+
+	return &failure, nil
+}
+
+// RecordFailure increments the failure counter and returns the new count. The
+// counter starts over when the previous failure is older than window.
+func (r *loginFailureRepository) RecordFailure(ctx context.Context, scope, key string, window time.Duration) (int, error) {
+	now := time.Now()
+
+	query := `
+		INSERT INTO login_failures (scope, key, failure_count, last_failure_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET failure_count = CASE
+		        WHEN login_failures.last_failure_at < $4 THEN 1
+		        ELSE login_failures.failure_count + 1
+		    END,
+		    last_failure_at = $3
+		RETURNING failure_count
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, scope, key, now, now.Add(-window)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *loginFailureRepository) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	query := `
+		UPDATE login_failures
+		SET locked_until = $1
+		WHERE scope = $2 AND key = $3
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, until, scope, key); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginFailureRepository) Reset(ctx context.Context, scope, key string) error {
+	query := `DELETE FROM login_failures WHERE scope = $1 AND key = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, scope, key); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
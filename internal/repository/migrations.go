@@ -109,6 +109,221 @@ func RunMigrations(db *sql.DB) error {
 	}
 	log.Println("✅ Table 'attendances' ready")
 
+	// Create sessions table
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS sessions (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			refresh_token_hash VARCHAR(64) UNIQUE NOT NULL,
+			previous_token_hash VARCHAR(64),
+			user_agent VARCHAR(500),
+			ip_address VARCHAR(64),
+			expires_at TIMESTAMP NOT NULL,
+			revoked_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT NOW(),
+			last_used_at TIMESTAMP DEFAULT NOW()
+		);
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Table 'sessions' ready")
+
+	// Add email verification column to users. Existing accounts are backfilled
+	// as verified so that enabling verification does not lock them out.
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP DEFAULT NOW();
+		ALTER TABLE users ALTER COLUMN email_verified_at DROP DEFAULT;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Column 'users.email_verified_at' ready")
+
+	// Create user_tokens table
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS user_tokens (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			token_hash VARCHAR(64) UNIQUE NOT NULL,
+			purpose VARCHAR(30) NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+			expires_at TIMESTAMP NOT NULL,
+			used_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT NOW()
+		);
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Table 'user_tokens' ready")
+
+	// Add avatar column to users
+	_, err = db.ExecContext(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_path VARCHAR(500);`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Column 'users.avatar_path' ready")
+
+	// Add account moderation columns to users
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Columns 'users.suspended_at', 'users.deleted_at' ready")
+
+	// Create login_failures table
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS login_failures (
+			scope VARCHAR(10) NOT NULL CHECK (scope IN ('account', 'ip')),
+			key VARCHAR(255) NOT NULL,
+			failure_count INT NOT NULL DEFAULT 0,
+			last_failure_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			locked_until TIMESTAMP,
+			PRIMARY KEY (scope, key)
+		);
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Table 'login_failures' ready")
+
+	// Add full-text search column to events. Titles weigh more than descriptions
+	// and both are indexed with the Indonesian and English stemmers.
+	_, err = db.ExecContext(ctx, `
+		CREATE EXTENSION IF NOT EXISTS pg_trgm;
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('indonesian', coalesce(description, '')), 'B') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Column 'events.search_vector' ready")
+
+	// Add waitlist offers to registrations. A freed seat can be held for the
+	// next waitlisted user until offer_expires_at.
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE registrations ADD COLUMN IF NOT EXISTS offer_expires_at TIMESTAMP;
+		ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+		ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+			CHECK (status IN ('registered', 'waitlist', 'offered', 'cancelled', 'attended'));
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Column 'registrations.offer_expires_at' ready")
+
+	// Add cancellation reasons. Cancelling an event cancels its registrations
+	// with the same reason so it shows up in each user's history.
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+		ALTER TABLE registrations ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Columns 'events.cancellation_reason' and 'registrations.cancellation_reason' ready")
+
+	// Add the self check-in window to events and record who marked each attendance
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_opens_at TIMESTAMP;
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_closes_at TIMESTAMP;
+		ALTER TABLE attendances ADD COLUMN IF NOT EXISTS marked_by UUID REFERENCES users(id) ON DELETE SET NULL;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Columns 'events.check_in_opens_at' and 'attendances.marked_by' ready")
+
+	// Add optional venue coordinates to events for geofenced self check-in
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_radius_meters INTEGER;
+		ALTER TABLE events DROP CONSTRAINT IF EXISTS events_geofence_check;
+		ALTER TABLE events ADD CONSTRAINT events_geofence_check CHECK (
+			(latitude IS NULL AND longitude IS NULL AND check_in_radius_meters IS NULL) OR
+			(latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180 AND check_in_radius_meters > 0)
+		);
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Columns 'events.latitude', 'events.longitude' and 'events.check_in_radius_meters' ready")
+
+	// Create attendance_history table
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS attendance_history (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			attendance_id UUID NOT NULL,
+			registration_id UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+			action VARCHAR(20) NOT NULL CHECK (action IN ('marked', 'notes_updated', 'removed')),
+			old_notes TEXT,
+			new_notes TEXT,
+			changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+			changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_attendance_history_registration ON attendance_history(registration_id, changed_at);
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Table 'attendance_history' ready")
+
+	// Create certificate_templates and certificates tables
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS certificate_templates (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			event_id UUID UNIQUE NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+			background_path VARCHAR(500) NOT NULL,
+			fields JSONB NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW()
+		);
+		CREATE TABLE IF NOT EXISTS certificates (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			registration_id UUID UNIQUE NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+			serial_number VARCHAR(32) UNIQUE NOT NULL,
+			participant_name VARCHAR(255) NOT NULL,
+			issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			emailed_at TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_certificates_not_emailed ON certificates(issued_at) WHERE emailed_at IS NULL;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Tables 'certificate_templates' and 'certificates' ready")
+
+	// Add certificate revocation, reported by public verification
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP;
+		ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_by UUID REFERENCES users(id) ON DELETE SET NULL;
+		ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revocation_reason TEXT;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Column 'certificates.revoked_at' ready")
+
+	// Add the iCalendar sequence of events and the personal calendar feed token of users
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE events ADD COLUMN IF NOT EXISTS calendar_sequence INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash VARCHAR(64) UNIQUE;
+	`)
+	if err != nil {
+		return err
+	}
+	log.Println("✅ Columns 'events.calendar_sequence' and 'users.calendar_token_hash' ready")
+
 	// Create indexes
 	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);`)
 	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);`)
@@ -119,6 +334,17 @@ func RunMigrations(db *sql.DB) error {
 	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_event ON registrations(event_id);`)
 	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_user ON registrations(user_id);`)
 	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_status ON registrations(status);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_sessions_previous_token ON sessions(previous_token_hash);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens(user_id, purpose);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_deadline ON events(registration_deadline, id);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_popularity ON events(current_participants, id);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_created ON events(created_at, id);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_user_registered ON registrations(user_id, registered_at, id);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_search ON events USING GIN(search_vector);`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_waitlist ON registrations(event_id, registered_at, id) WHERE status = 'waitlist';`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_registrations_offer_expiry ON registrations(offer_expires_at) WHERE status = 'offered';`)
+	db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_title_trgm ON events USING GIN(title gin_trgm_ops);`)
 	log.Println("✅ Indexes created")
 
 	// Insert default admin if not exists
@@ -127,8 +353,8 @@ func RunMigrations(db *sql.DB) error {
 	if err == nil && count == 0 {
 		// Password: admin123 (bcrypt hash)
 		_, err = db.ExecContext(ctx, `
-			INSERT INTO users (email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			INSERT INTO users (email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved, email_verified_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 		`, "admin@uii.ac.id",
 			"$2a$10$K7mZ3vGGqG9vY8xQVH9Q8eN4xW3rZ8KF7qY5xH9Q8eN4xW3rZ8KF7q",
 			"System Administrator",
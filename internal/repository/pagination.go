@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidListOptions is returned for unknown sort keys, sort orders or malformed cursors
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+// Sort orders accepted by ListOptions
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// ListOptions holds pagination and sorting for list queries.
+// Limit 0 returns every row. Cursor, when set, takes precedence over Page.
+type ListOptions struct {
+	Page   int
+	Limit  int
+	Sort   string // sort key, empty uses the query default
+	Order  string // asc or desc, empty uses the query default
+	Cursor string // next_cursor of the previous page
+
+	after *cursor // decoded Cursor, set by sortSpec.resolve
+}
+
+// PageInfo describes the page returned by a list query
+type PageInfo struct {
+	TotalItems int
+	NextCursor string // empty on the last page
+}
+
+// sortColumn maps a sort key to a column
+type sortColumn struct {
+	column string
+	cast   string // SQL type the cursor value is cast to
+}
+
+// sortSpec lists the sort keys a list query accepts
+type sortSpec struct {
+	columns      map[string]sortColumn
+	idColumn     string // tie-breaker, keeps the order stable for keyset pagination
+	defaultSort  string
+	defaultOrder string
+}
+
+// cursor points at the last row of the previous page
+type cursor struct {
+	sort  string
+	order string
+	value string
+	id    uuid.UUID
+}
+
+// encode serializes a cursor into an opaque URL-safe string
+func (c cursor) encode() string {
+	raw := strings.Join([]string{c.sort, c.order, c.value, c.id.String()}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor parses a cursor produced by cursor.encode
+func decodeCursor(s string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListOptions)
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListOptions)
+	}
+
+	id, err := uuid.Parse(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListOptions)
+	}
+
+	return &cursor{sort: parts[0], order: parts[1], value: parts[2], id: id}, nil
+}
+
+// resolve fills in default sorting and validates opts against the spec
+func (s sortSpec) resolve(opts ListOptions) (ListOptions, error) {
+	if opts.Sort == "" {
+		opts.Sort = s.defaultSort
+	}
+	if opts.Order == "" {
+		opts.Order = s.defaultOrder
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+
+	if _, ok := s.columns[opts.Sort]; !ok {
+		return opts, fmt.Errorf("%w: unknown sort key %q", ErrInvalidListOptions, opts.Sort)
+	}
+	if opts.Order != SortAsc && opts.Order != SortDesc {
+		return opts, fmt.Errorf("%w: order must be asc or desc", ErrInvalidListOptions)
+	}
+
+	if opts.Cursor != "" {
+		after, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return opts, err
+		}
+		if after.sort != opts.Sort || after.order != opts.Order {
+			return opts, fmt.Errorf("%w: cursor does not match sort and order", ErrInvalidListOptions)
+		}
+		opts.after = after
+	}
+
+	return opts, nil
+}
+
+// paginate appends the keyset condition, ORDER BY and LIMIT/OFFSET to a query whose
+// WHERE clause is already in place. One extra row is fetched to detect the last page.
+func (s sortSpec) paginate(query string, args []interface{}, opts ListOptions) (string, []interface{}) {
+	col := s.columns[opts.Sort]
+	direction := "DESC"
+	comparison := "<"
+	if opts.Order == SortAsc {
+		direction = "ASC"
+		comparison = ">"
+	}
+
+	if opts.after != nil {
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d::%s, $%d)",
+			col.column, s.idColumn, comparison, len(args)+1, col.cast, len(args)+2)
+		args = append(args, opts.after.value, opts.after.id)
+	}
+
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s", col.column, direction, s.idColumn, direction)
+
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, opts.Limit+1)
+
+		if opts.after == nil {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, (opts.Page-1)*opts.Limit)
+		}
+	}
+
+	return query, args
+}
+
+// nextCursor builds the cursor following a row with the given sort value and ID
+func (opts ListOptions) nextCursor(value string, id uuid.UUID) string {
+	return cursor{sort: opts.Sort, order: opts.Order, value: value, id: id}.encode()
+}
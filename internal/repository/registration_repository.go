@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"event-campus-backend/internal/domain"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,23 +14,63 @@ import (
 // RegistrationRepository defines interface for registration data access
 type RegistrationRepository interface {
 	Create(ctx context.Context, registration *domain.Registration) error
+	CreateWithSeat(ctx context.Context, registration *domain.Registration) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Registration, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Registration, error)
 	GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) (*domain.Registration, error)
 	GetByEvent(ctx context.Context, eventID uuid.UUID, status string) ([]domain.Registration, error)
-	GetByUser(ctx context.Context, userID uuid.UUID) ([]domain.Registration, error)
+	StreamByEvent(ctx context.Context, eventID uuid.UUID, attendedOnly bool, fn func(*domain.Registration) error) error
+	GetByUser(ctx context.Context, userID uuid.UUID, opts ListOptions) ([]domain.Registration, *PageInfo, error)
 	Update(ctx context.Context, registration *domain.Registration) error
 	Cancel(ctx context.Context, id uuid.UUID) error
+	CancelByEvent(ctx context.Context, eventID uuid.UUID, reason string) ([]domain.Registration, error)
 	GetWaitlistByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Registration, error)
 	PromoteFromWaitlist(ctx context.Context, eventID uuid.UUID) (*domain.Registration, error)
+	OfferFromWaitlist(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) (*domain.Registration, error)
+	FillFromWaitlist(ctx context.Context, eventID uuid.UUID) ([]domain.Registration, error)
+	ClaimOffer(ctx context.Context, id uuid.UUID) error
+	ExpireOffer(ctx context.Context, id uuid.UUID) (bool, error)
+	MarkAttended(ctx context.Context, id uuid.UUID) (bool, error)
+	UnmarkAttended(ctx context.Context, id uuid.UUID) (bool, error)
+	GetExpiredOffers(ctx context.Context) ([]domain.Registration, error)
+	GetWaitlistPosition(ctx context.Context, id uuid.UUID) (int, error)
 	CountByEventAndStatus(ctx context.Context, eventID uuid.UUID, status string) (int, error)
 }
 
+// registrationColumns lists the registrations columns in the order scanRegistration
+// expects them, selected FROM registrations r
+const registrationColumns = `r.id, r.event_id, r.user_id, r.status, r.registered_at, r.cancelled_at,
+		       r.reminder_sent, r.offer_expires_at, r.cancellation_reason`
+
+// waitlistPositionColumn computes the 1-based waitlist position of r, NULL unless waitlisted
+const waitlistPositionColumn = `CASE WHEN r.status = 'waitlist' THEN (
+			SELECT COUNT(*) FROM registrations w
+			WHERE w.event_id = r.event_id AND w.status = 'waitlist'
+			  AND (w.registered_at, w.id) <= (r.registered_at, r.id)
+		) END`
+
+// Registration sort keys accepted by GetByUser
+const (
+	RegistrationSortRegistered = "registered"
+	RegistrationSortEventStart = "start_date"
+)
+
+var registrationSorts = sortSpec{
+	columns: map[string]sortColumn{
+		RegistrationSortRegistered: {column: "r.registered_at", cast: "timestamp"},
+		RegistrationSortEventStart: {column: "e.start_date", cast: "timestamp"},
+	},
+	idColumn:     "r.id",
+	defaultSort:  RegistrationSortRegistered,
+	defaultOrder: SortDesc,
+}
+
 type registrationRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewRegistrationRepository creates a new registration repository
-func NewRegistrationRepository(db *sql.DB) RegistrationRepository {
+func NewRegistrationRepository(db DBTX) RegistrationRepository {
 	return &registrationRepository{
 		db: db,
 	}
@@ -65,26 +106,73 @@ func (r *registrationRepository) Create(ctx context.Context, registration *domai
 	return nil
 }
 
-func (r *registrationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Registration, error) {
-	query := `
-		SELECT id, event_id, user_id, status, registered_at, cancelled_at, reminder_sent
-		FROM registrations
-		WHERE id = $1
-	`
+// CreateWithSeat inserts a registration and claims a seat for it in one transaction.
+// The event row is locked until commit so concurrent registrations cannot oversell
+// the last seats: the registration is registered while seats remain, waitlisted after.
+func (r *registrationRepository) CreateWithSeat(ctx context.Context, registration *domain.Registration) error {
+	if registration.ID == uuid.Nil {
+		registration.ID = uuid.New()
+	}
+	registration.RegisteredAt = time.Now()
 
-	var registration domain.Registration
-	var cancelledAt sql.NullTime
+	return inTx(ctx, r.db, func(tx DBTX) error {
+		var currentParticipants, maxParticipants int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COALESCE(current_participants, 0), max_participants
+			FROM events
+			WHERE id = $1
+			FOR UPDATE
+		`, registration.EventID).Scan(&currentParticipants, &maxParticipants)
+
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("event not found")
+		}
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&registration.ID,
-		&registration.EventID,
-		&registration.UserID,
-		&registration.Status,
-		&registration.RegisteredAt,
-		&cancelledAt,
-		&registration.ReminderSent,
-	)
+		if err != nil {
+			return fmt.Errorf("failed to lock event: %w", err)
+		}
+
+		registration.Status = domain.RegistrationStatusRegistered
+		if currentParticipants >= maxParticipants {
+			registration.Status = domain.RegistrationStatusWaitlist
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO registrations (id, event_id, user_id, status, registered_at, reminder_sent)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`,
+			registration.ID,
+			registration.EventID,
+			registration.UserID,
+			registration.Status,
+			registration.RegisteredAt,
+			registration.ReminderSent,
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to create registration: %w", err)
+		}
+
+		if registration.IsRegistered() {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE events
+				SET current_participants = COALESCE(current_participants, 0) + 1, updated_at = $1
+				WHERE id = $2
+			`, time.Now(), registration.EventID)
+
+			if err != nil {
+				return fmt.Errorf("failed to increment participants: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *registrationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Registration, error) {
+	query := "SELECT " + registrationColumns + " FROM registrations r WHERE r.id = $1"
 
+	registration, err := scanRegistration(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("registration not found")
 	}
@@ -93,35 +181,35 @@ func (r *registrationRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 		return nil, fmt.Errorf("failed to get registration: %w", err)
 	}
 
-	if cancelledAt.Valid {
-		registration.CancelledAt = &cancelledAt.Time
+	return registration, nil
+}
+
+// GetByIDForUpdate gets a registration and locks its row until the surrounding
+// transaction ends, so its status cannot change while a decision is made on it
+func (r *registrationRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Registration, error) {
+	query := "SELECT " + registrationColumns + " FROM registrations r WHERE r.id = $1 FOR UPDATE"
+
+	registration, err := scanRegistration(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("registration not found")
 	}
 
-	return &registration, nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+
+	return registration, nil
 }
 
 func (r *registrationRepository) GetByUserAndEvent(ctx context.Context, userID, eventID uuid.UUID) (*domain.Registration, error) {
-	query := `
-		SELECT id, event_id, user_id, status, registered_at, cancelled_at, reminder_sent
-		FROM registrations
-		WHERE user_id = $1 AND event_id = $2
-		ORDER BY registered_at DESC
+	query := "SELECT " + registrationColumns + `
+		FROM registrations r
+		WHERE r.user_id = $1 AND r.event_id = $2
+		ORDER BY r.registered_at DESC
 		LIMIT 1
 	`
 
-	var registration domain.Registration
-	var cancelledAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, userID, eventID).Scan(
-		&registration.ID,
-		&registration.EventID,
-		&registration.UserID,
-		&registration.Status,
-		&registration.RegisteredAt,
-		&cancelledAt,
-		&registration.ReminderSent,
-	)
-
+	registration, err := scanRegistration(r.db.QueryRowContext(ctx, query, userID, eventID))
 	if err == sql.ErrNoRows {
 		return nil, nil // Not found is not an error
 	}
@@ -130,35 +218,76 @@ func (r *registrationRepository) GetByUserAndEvent(ctx context.Context, userID,
 		return nil, fmt.Errorf("failed to get registration: %w", err)
 	}
 
-	if cancelledAt.Valid {
-		registration.CancelledAt = &cancelledAt.Time
+	return registration, nil
+}
+
+func (r *registrationRepository) GetByEvent(ctx context.Context, eventID uuid.UUID, status string) ([]domain.Registration, error) {
+	query := "SELECT " + registrationColumns + " FROM registrations r WHERE r.event_id = $1"
+	args := []interface{}{eventID}
+
+	if status != "" {
+		query += " AND r.status = $2"
+		args = append(args, status)
 	}
+	query += " ORDER BY r.registered_at ASC, r.id ASC"
 
-	return &registration, nil
+	return r.query(ctx, query, args...)
 }
 
-func (r *registrationRepository) GetByEvent(ctx context.Context, eventID uuid.UUID, status string) ([]domain.Registration, error) {
-	var query string
-	var args []interface{}
+// StreamByEvent calls fn for each registration of an event, with the user's contact details
+// and attendance, as rows are read so large events are never held in memory.
+// attendedOnly limits it to registrations with an attendance.
+func (r *registrationRepository) StreamByEvent(ctx context.Context, eventID uuid.UUID, attendedOnly bool, fn func(*domain.Registration) error) error {
+	query := "SELECT " + registrationColumns + `, u.full_name, u.email, u.phone_number, a.checked_in_at, a.notes
+		FROM registrations r
+		JOIN users u ON r.user_id = u.id
+		LEFT JOIN attendances a ON a.registration_id = r.id
+		WHERE r.event_id = $1`
+	if attendedOnly {
+		query += " AND a.id IS NOT NULL"
+	}
+	query += " ORDER BY r.registered_at ASC, r.id ASC"
 
-	if status == "" {
-		query = `
-			SELECT id, event_id, user_id, status, registered_at, cancelled_at, reminder_sent
-			FROM registrations
-			WHERE event_id = $1
-			ORDER BY registered_at ASC
-		`
-		args = append(args, eventID)
-	} else {
-		query = `
-			SELECT id, event_id, user_id, status, registered_at, cancelled_at, reminder_sent
-			FROM registrations
-			WHERE event_id = $1 AND status = $2
-			ORDER BY registered_at ASC
-		`
-		args = append(args, eventID, status)
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get registrations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userName, userEmail, userPhone string
+		var attendedAt sql.NullTime
+		var notes sql.NullString
+
+		registration, err := scanRegistration(rows, &userName, &userEmail, &userPhone, &attendedAt, &notes)
+		if err != nil {
+			return fmt.Errorf("failed to scan registration: %w", err)
+		}
+
+		registration.UserName = &userName
+		registration.UserEmail = &userEmail
+		registration.UserPhone = &userPhone
+		if attendedAt.Valid {
+			registration.AttendedAt = &attendedAt.Time
+		}
+		if notes.Valid {
+			registration.AttendanceNotes = &notes.String
+		}
+
+		if err := fn(registration); err != nil {
+			return err
+		}
 	}
 
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate registrations: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a query selecting registrationColumns
+func (r *registrationRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Registration, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get registrations: %w", err)
@@ -167,74 +296,76 @@ func (r *registrationRepository) GetByEvent(ctx context.Context, eventID uuid.UU
 
 	var registrations []domain.Registration
 	for rows.Next() {
-		var registration domain.Registration
-		var cancelledAt sql.NullTime
-
-		err := rows.Scan(
-			&registration.ID,
-			&registration.EventID,
-			&registration.UserID,
-			&registration.Status,
-			&registration.RegisteredAt,
-			&cancelledAt,
-			&registration.ReminderSent,
-		)
-
+		registration, err := scanRegistration(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan registration: %w", err)
 		}
 
-		if cancelledAt.Valid {
-			registration.CancelledAt = &cancelledAt.Time
-		}
-
-		registrations = append(registrations, registration)
+		registrations = append(registrations, *registration)
 	}
 
 	return registrations, nil
 }
 
-func (r *registrationRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]domain.Registration, error) {
-	query := `
-		SELECT id, event_id, user_id, status, registered_at, cancelled_at, reminder_sent
-		FROM registrations
-		WHERE user_id = $1
-		ORDER BY registered_at DESC
-	`
+func (r *registrationRepository) GetByUser(ctx context.Context, userID uuid.UUID, opts ListOptions) ([]domain.Registration, *PageInfo, error) {
+	opts, err := registrationSorts.resolve(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := " FROM registrations r JOIN events e ON e.id = r.event_id WHERE r.user_id = $1"
+	args := []interface{}{userID}
+
+	page := &PageInfo{}
+	if opts.Limit > 0 {
+		if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&page.TotalItems); err != nil {
+			return nil, nil, fmt.Errorf("failed to count registrations: %w", err)
+		}
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	query, args := registrationSorts.paginate(
+		"SELECT "+registrationColumns+", "+waitlistPositionColumn+", e.start_date"+from,
+		args, opts)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get registrations: %w", err)
+		return nil, nil, fmt.Errorf("failed to get registrations: %w", err)
 	}
 	defer rows.Close()
 
 	var registrations []domain.Registration
+	var eventStartDates []time.Time
 	for rows.Next() {
-		var registration domain.Registration
-		var cancelledAt sql.NullTime
-
-		err := rows.Scan(
-			&registration.ID,
-			&registration.EventID,
-			&registration.UserID,
-			&registration.Status,
-			&registration.RegisteredAt,
-			&cancelledAt,
-			&registration.ReminderSent,
-		)
+		var waitlistPosition sql.NullInt64
+		var eventStartDate time.Time
 
+		registration, err := scanRegistration(rows, &waitlistPosition, &eventStartDate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan registration: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan registration: %w", err)
 		}
 
-		if cancelledAt.Valid {
-			registration.CancelledAt = &cancelledAt.Time
+		if waitlistPosition.Valid {
+			position := int(waitlistPosition.Int64)
+			registration.WaitlistPosition = &position
 		}
 
-		registrations = append(registrations, registration)
+		registrations = append(registrations, *registration)
+		eventStartDates = append(eventStartDates, eventStartDate)
 	}
 
-	return registrations, nil
+	if opts.Limit == 0 {
+		page.TotalItems = len(registrations)
+	} else if len(registrations) > opts.Limit {
+		registrations = registrations[:opts.Limit]
+		last := registrations[len(registrations)-1]
+		sortValue := last.RegisteredAt
+		if opts.Sort == RegistrationSortEventStart {
+			sortValue = eventStartDates[opts.Limit-1]
+		}
+		page.NextCursor = opts.nextCursor(sortValue.Format(time.RFC3339Nano), last.ID)
+	}
+
+	return registrations, page, nil
 }
 
 func (r *registrationRepository) Update(ctx context.Context, registration *domain.Registration) error {
@@ -267,16 +398,25 @@ func (r *registrationRepository) Update(ctx context.Context, registration *domai
 	return nil
 }
 
+// Cancel cancels a registered, waitlisted or offered registration. It fails when the
+// registration was cancelled, expired or attended in the meantime.
 func (r *registrationRepository) Cancel(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
 
 	query := `
 		UPDATE registrations
 		SET status = $1, cancelled_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND status IN ($4, $5, $6)
 	`
 
-	result, err := r.db.ExecContext(ctx, query, domain.RegistrationStatusCancelled, now, id)
+	result, err := r.db.ExecContext(ctx, query,
+		domain.RegistrationStatusCancelled,
+		now,
+		id,
+		domain.RegistrationStatusRegistered,
+		domain.RegistrationStatusWaitlist,
+		domain.RegistrationStatusOffered,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to cancel registration: %w", err)
 	}
@@ -287,36 +427,322 @@ func (r *registrationRepository) Cancel(ctx context.Context, id uuid.UUID) error
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("registration not found")
+		return fmt.Errorf("registration can no longer be cancelled")
 	}
 
 	return nil
 }
 
+// CancelByEvent cancels every registered, waitlisted or offered registration of
+// the event with reason. Returns the cancelled registrations.
+func (r *registrationRepository) CancelByEvent(ctx context.Context, eventID uuid.UUID, reason string) ([]domain.Registration, error) {
+	query := `
+		UPDATE registrations r
+		SET status = $1, cancelled_at = $2, cancellation_reason = $3, offer_expires_at = NULL
+		WHERE r.event_id = $4 AND r.status IN ($5, $6, $7)
+		RETURNING ` + registrationColumns
+
+	rows, err := r.db.QueryContext(ctx, query,
+		domain.RegistrationStatusCancelled,
+		time.Now(),
+		reason,
+		eventID,
+		domain.RegistrationStatusRegistered,
+		domain.RegistrationStatusWaitlist,
+		domain.RegistrationStatusOffered,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel registrations: %w", err)
+	}
+	defer rows.Close()
+
+	var registrations []domain.Registration
+	for rows.Next() {
+		registration, err := scanRegistration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registration: %w", err)
+		}
+		registrations = append(registrations, *registration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to cancel registrations: %w", err)
+	}
+
+	return registrations, nil
+}
+
 func (r *registrationRepository) GetWaitlistByEvent(ctx context.Context, eventID uuid.UUID) ([]domain.Registration, error) {
 	return r.GetByEvent(ctx, eventID, domain.RegistrationStatusWaitlist)
 }
 
 func (r *registrationRepository) PromoteFromWaitlist(ctx context.Context, eventID uuid.UUID) (*domain.Registration, error) {
-	// Get first person in waitlist (FIFO)
-	waitlist, err := r.GetWaitlistByEvent(ctx, eventID)
+	return r.takeFromWaitlist(ctx, eventID, domain.RegistrationStatusRegistered, nil)
+}
+
+func (r *registrationRepository) OfferFromWaitlist(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) (*domain.Registration, error) {
+	return r.takeFromWaitlist(ctx, eventID, domain.RegistrationStatusOffered, &expiresAt)
+}
+
+// takeFromWaitlist moves the first person in waitlist (FIFO) to status. Rows locked
+// by a concurrent promotion are skipped so one person is never taken twice.
+func (r *registrationRepository) takeFromWaitlist(ctx context.Context, eventID uuid.UUID, status string, offerExpiresAt *time.Time) (*domain.Registration, error) {
+	query := `
+		UPDATE registrations r
+		SET status = $1, offer_expires_at = $2
+		WHERE r.id = (
+			SELECT id
+			FROM registrations
+			WHERE event_id = $3 AND status = $4
+			ORDER BY registered_at ASC, id ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + registrationColumns
+
+	registration, err := scanRegistration(r.db.QueryRowContext(ctx, query,
+		status,
+		offerExpiresAt,
+		eventID,
+		domain.RegistrationStatusWaitlist,
+	))
+
+	if err == sql.ErrNoRows {
+		return nil, nil // No one in waitlist
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote from waitlist: %w", err)
+	}
+
+	return registration, nil
+}
+
+// FillFromWaitlist promotes waitlisted registrations (FIFO) into the event's free
+// seats and counts them as participants. Returns the promoted registrations.
+func (r *registrationRepository) FillFromWaitlist(ctx context.Context, eventID uuid.UUID) ([]domain.Registration, error) {
+	var registrations []domain.Registration
+
+	err := inTx(ctx, r.db, func(tx DBTX) error {
+		var currentParticipants, maxParticipants int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COALESCE(current_participants, 0), max_participants
+			FROM events
+			WHERE id = $1
+			FOR UPDATE
+		`, eventID).Scan(&currentParticipants, &maxParticipants)
+
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("event not found")
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to lock event: %w", err)
+		}
+
+		freeSeats := maxParticipants - currentParticipants
+		if freeSeats <= 0 {
+			return nil
+		}
+
+		query := `
+			UPDATE registrations r
+			SET status = $1, offer_expires_at = NULL
+			WHERE r.id IN (
+				SELECT id
+				FROM registrations
+				WHERE event_id = $2 AND status = $3
+				ORDER BY registered_at ASC, id ASC
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + registrationColumns
+
+		rows, err := tx.QueryContext(ctx, query,
+			domain.RegistrationStatusRegistered,
+			eventID,
+			domain.RegistrationStatusWaitlist,
+			freeSeats,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to promote from waitlist: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			registration, err := scanRegistration(rows)
+			if err != nil {
+				return fmt.Errorf("failed to scan registration: %w", err)
+			}
+			registrations = append(registrations, *registration)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to promote from waitlist: %w", err)
+		}
+
+		if len(registrations) == 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE events
+			SET current_participants = COALESCE(current_participants, 0) + $1, updated_at = $2
+			WHERE id = $3
+		`, len(registrations), time.Now(), eventID)
+
+		if err != nil {
+			return fmt.Errorf("failed to increment participants: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(waitlist) == 0 {
-		return nil, nil // No one in waitlist
+	// RETURNING does not keep the waitlist order
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].RegisteredAt.Before(registrations[j].RegisteredAt)
+	})
+
+	return registrations, nil
+}
+
+func (r *registrationRepository) ClaimOffer(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE registrations
+		SET status = $1, offer_expires_at = NULL
+		WHERE id = $2 AND status = $3 AND offer_expires_at > $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.RegistrationStatusRegistered,
+		id,
+		domain.RegistrationStatusOffered,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to claim offer: %w", err)
 	}
 
-	// Promote first person
-	firstInLine := &waitlist[0]
-	firstInLine.Status = domain.RegistrationStatusRegistered
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
 
-	if err := r.Update(ctx, firstInLine); err != nil {
-		return nil, fmt.Errorf("failed to promote from waitlist: %w", err)
+	if rows == 0 {
+		return fmt.Errorf("offer has expired or is no longer available")
 	}
 
-	return firstInLine, nil
+	return nil
+}
+
+// ExpireOffer cancels an offer whose claim deadline has passed. It reports false
+// when the offer was claimed or cancelled in the meantime.
+func (r *registrationRepository) ExpireOffer(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+
+	query := `
+		UPDATE registrations
+		SET status = $1, cancelled_at = $2
+		WHERE id = $3 AND status = $4 AND offer_expires_at <= $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.RegistrationStatusCancelled,
+		now,
+		id,
+		domain.RegistrationStatusOffered,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to expire offer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// MarkAttended moves a registered registration to attended. Returns false when it
+// was not registered anymore, e.g. because a concurrent check-in got there first.
+func (r *registrationRepository) MarkAttended(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE registrations
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.RegistrationStatusAttended,
+		id,
+		domain.RegistrationStatusRegistered,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark registration attended: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// UnmarkAttended moves an attended registration back to registered. Returns false
+// when it was not attended, e.g. because a concurrent undo got there first.
+func (r *registrationRepository) UnmarkAttended(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE registrations
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.RegistrationStatusRegistered,
+		id,
+		domain.RegistrationStatusAttended,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore registration: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *registrationRepository) GetExpiredOffers(ctx context.Context) ([]domain.Registration, error) {
+	query := "SELECT " + registrationColumns + `
+		FROM registrations r
+		WHERE r.status = $1 AND r.offer_expires_at <= $2
+		ORDER BY r.offer_expires_at ASC
+	`
+
+	return r.query(ctx, query, domain.RegistrationStatusOffered, time.Now())
+}
+
+func (r *registrationRepository) GetWaitlistPosition(ctx context.Context, id uuid.UUID) (int, error) {
+	query := "SELECT COALESCE(" + waitlistPositionColumn + ", 0) FROM registrations r WHERE r.id = $1"
+
+	var position int
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&position)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("registration not found")
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get waitlist position: %w", err)
+	}
+
+	return position, nil
 }
 
 func (r *registrationRepository) CountByEventAndStatus(ctx context.Context, eventID uuid.UUID, status string) (int, error) {
@@ -334,3 +760,38 @@ func (r *registrationRepository) CountByEventAndStatus(ctx context.Context, even
 
 	return count, nil
 }
+
+// scanRegistration scans a row selected with registrationColumns, followed by any extra columns
+func scanRegistration(row rowScanner, extra ...interface{}) (*domain.Registration, error) {
+	var registration domain.Registration
+	var cancelledAt, offerExpiresAt sql.NullTime
+	var cancellationReason sql.NullString
+
+	dest := []interface{}{
+		&registration.ID,
+		&registration.EventID,
+		&registration.UserID,
+		&registration.Status,
+		&registration.RegisteredAt,
+		&cancelledAt,
+		&registration.ReminderSent,
+		&offerExpiresAt,
+		&cancellationReason,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if cancelledAt.Valid {
+		registration.CancelledAt = &cancelledAt.Time
+	}
+	if offerExpiresAt.Valid {
+		registration.OfferExpiresAt = &offerExpiresAt.Time
+	}
+	if cancellationReason.Valid {
+		s := cancellationReason.String
+		registration.CancellationReason = &s
+	}
+
+	return &registration, nil
+}
@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateWithSeatConcurrentRegistrations(t *testing.T) {
+	db := testutil.DB(t)
+	db.SetMaxOpenConns(20)
+	ctx := context.Background()
+
+	const maxParticipants = 5
+	const registrations = 200
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, maxParticipants)
+
+	userIDs := make([]uuid.UUID, registrations)
+	for i := range userIDs {
+		userIDs[i] = testutil.CreateUser(t, db, domain.RoleMahasiswa).ID
+	}
+
+	repo := repository.NewRegistrationRepository(db)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, registrations)
+	for _, userID := range userIDs {
+		wg.Add(1)
+		go func(userID uuid.UUID) {
+			defer wg.Done()
+			errs <- repo.CreateWithSeat(ctx, &domain.Registration{EventID: event.ID, UserID: userID})
+		}(userID)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("CreateWithSeat: %v", err)
+		}
+	}
+
+	var currentParticipants, registered, waitlisted int
+	err := db.QueryRowContext(ctx, `
+		SELECT e.current_participants,
+		       (SELECT COUNT(*) FROM registrations WHERE event_id = e.id AND status = 'registered'),
+		       (SELECT COUNT(*) FROM registrations WHERE event_id = e.id AND status = 'waitlist')
+		FROM events e
+		WHERE e.id = $1
+	`, event.ID).Scan(&currentParticipants, &registered, &waitlisted)
+	if err != nil {
+		t.Fatalf("failed to count registrations: %v", err)
+	}
+
+	if currentParticipants > maxParticipants {
+		t.Errorf("current_participants = %d, exceeds max_participants %d", currentParticipants, maxParticipants)
+	}
+	if registered != currentParticipants {
+		t.Errorf("%d registered rows, current_participants = %d", registered, currentParticipants)
+	}
+	if registered != maxParticipants {
+		t.Errorf("%d registered rows, want every one of the %d seats taken", registered, maxParticipants)
+	}
+	if registered+waitlisted != registrations {
+		t.Errorf("%d registered and %d waitlisted, want %d registrations", registered, waitlisted, registrations)
+	}
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionRepository defines interface for session data access
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error)
+	GetByPreviousTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error)
+	Rotate(ctx context.Context, id uuid.UUID, oldTokenHash, newTokenHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllByUser(ctx context.Context, userID uuid.UUID) error
+	RevokeAllByUserExcept(ctx context.Context, userID, keepSessionID uuid.UUID) error
+}
+
+type sessionRepository struct {
+	db DBTX
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db DBTX) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	// Generate ID if not set
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	session.CreatedAt = now
+	session.LastUsedAt = now
+
+	query := `
+		INSERT INTO sessions (id, user_id, refresh_token_hash, user_agent, ip_address, expires_at, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+		session.CreatedAt,
+		session.LastUsedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address,
+		       expires_at, revoked_at, created_at, last_used_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	session, err := r.scanOne(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address,
+		       expires_at, revoked_at, created_at, last_used_at
+		FROM sessions
+		WHERE refresh_token_hash = $1
+	`
+
+	session, err := r.scanOne(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) GetByPreviousTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address,
+		       expires_at, revoked_at, created_at, last_used_at
+		FROM sessions
+		WHERE previous_token_hash = $1
+	`
+
+	session, err := r.scanOne(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldTokenHash, newTokenHash string, expiresAt time.Time) error {
+	// Only rotate if the presented token is still the current one, so two
+	// concurrent refreshes with the same token cannot both succeed
+	query := `
+		UPDATE sessions
+		SET refresh_token_hash = $1, previous_token_hash = $2, expires_at = $3, last_used_at = $4
+		WHERE id = $5 AND refresh_token_hash = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, newTokenHash, oldTokenHash, expiresAt, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("session not found or already rotated")
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) scanOne(row *sql.Row) (*domain.Session, error) {
+	var session domain.Session
+	var previousTokenHash, userAgent, ipAddress sql.NullString
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&previousTokenHash,
+		&userAgent,
+		&ipAddress,
+		&session.ExpiresAt,
+		&revokedAt,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if previousTokenHash.Valid {
+		s := previousTokenHash.String
+		session.PreviousTokenHash = &s
+	}
+	if userAgent.Valid {
+		s := userAgent.String
+		session.UserAgent = &s
+	}
+	if ipAddress.Valid {
+		s := ipAddress.String
+		session.IPAddress = &s
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) RevokeAllByUserExcept(ctx context.Context, userID, keepSessionID uuid.UUID) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $1
+		WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID, keepSessionID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is satisfied by both *sql.DB and *sql.Tx, so repositories can run
+// either on the connection pool or inside a transaction
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txBeginner is implemented by *sql.DB but not by *sql.Tx
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Repositories groups repositories bound to the same transaction
+type Repositories struct {
+	Users         UserRepository
+	Whitelist     WhitelistRepository
+	Events        EventRepository
+	Registrations RegistrationRepository
+	Attendances   AttendanceRepository
+	Certificates  CertificateRepository
+}
+
+// TxManager runs units of work that must succeed or fail as a whole
+type TxManager interface {
+	// WithinTx calls fn with repositories bound to a new transaction. The
+	// transaction is committed when fn returns nil and rolled back otherwise.
+	WithinTx(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type sqlTxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager creates a transaction manager on the given connection pool
+func NewTxManager(db *sql.DB) TxManager {
+	return &sqlTxManager{
+		db: db,
+	}
+}
+
+func (m *sqlTxManager) WithinTx(ctx context.Context, fn func(repos Repositories) error) error {
+	return inTx(ctx, m.db, func(tx DBTX) error {
+		return fn(Repositories{
+			Users:         NewUserRepository(tx),
+			Whitelist:     NewWhitelistRepository(tx),
+			Events:        NewEventRepository(tx),
+			Registrations: NewRegistrationRepository(tx),
+			Attendances:   NewAttendanceRepository(tx),
+			Certificates:  NewCertificateRepository(tx),
+		})
+	})
+}
+
+// inTx runs fn in a new transaction on db. When db is already a transaction,
+// fn joins it and the outermost unit of work decides whether to commit.
+func inTx(ctx context.Context, db DBTX, fn func(tx DBTX) error) error {
+	beginner, ok := db.(txBeginner)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
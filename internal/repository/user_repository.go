@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"event-campus-backend/internal/domain"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,17 +17,46 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetByCalendarTokenHash(ctx context.Context, tokenHash string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	UpdateRole(ctx context.Context, userID uuid.UUID, role string, isApproved bool) error
+	UpdateProfile(ctx context.Context, userID uuid.UUID, fullName, phoneNumber string) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarPath string) (*string, error)
+	SetSuspension(ctx context.Context, userID uuid.UUID, at *time.Time, reason *string) error
+	SoftDelete(ctx context.Context, userID uuid.UUID) error
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID) error
+	SetCalendarTokenHash(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	List(ctx context.Context, filter UserFilter) ([]domain.User, int, error)
 }
 
+// User account statuses accepted by UserFilter
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusDeleted   = "deleted"
+)
+
+// UserFilter holds admin user listing filters
+type UserFilter struct {
+	Search string // matches full name or email
+	Role   string
+	Status string // active, suspended or deleted; empty lists every user that is not deleted
+	Page   int
+	Limit  int
+}
+
+// userColumns lists the users columns in the order scanUser expects them
+const userColumns = `id, email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved,
+		       email_verified_at, avatar_path, suspended_at, suspension_reason, deleted_at, created_at, updated_at`
+
 // postgresUserRepository implements UserRepository with PostgreSQL
 type postgresUserRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewUserRepository creates a new user repository with PostgreSQL
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db DBTX) UserRepository {
 	return &postgresUserRepository{
 		db: db,
 	}
@@ -51,8 +81,8 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User)
 
 	// Insert into database
 	query := `
-		INSERT INTO users (id, email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO users (id, email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved, email_verified_at, avatar_path, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -64,6 +94,8 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User)
 		user.Role,
 		user.IsUIICivitas,
 		user.IsApproved,
+		user.EmailVerifiedAt,
+		user.AvatarPath,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -77,25 +109,12 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User)
 
 func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	var user domain.User
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FullName,
-		&user.PhoneNumber,
-		&user.Role,
-		&user.IsUIICivitas,
-		&user.IsApproved,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
 	}
@@ -104,30 +123,36 @@ func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, full_name, phone_number, role, is_uii_civitas, is_approved, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	var user domain.User
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FullName,
-		&user.PhoneNumber,
-		&user.Role,
-		&user.IsUIICivitas,
-		&user.IsApproved,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *postgresUserRepository) GetByCalendarTokenHash(ctx context.Context, tokenHash string) (*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE calendar_token_hash = $1
+	`
 
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, tokenHash))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
 	}
@@ -136,7 +161,7 @@ func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User) error {
@@ -145,8 +170,9 @@ func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User)
 	query := `
 		UPDATE users
 		SET email = $1, password_hash = $2, full_name = $3, phone_number = $4, 
-		    role = $5, is_uii_civitas = $6, is_approved = $7, updated_at = $8
-		WHERE id = $9
+		    role = $5, is_uii_civitas = $6, is_approved = $7, email_verified_at = $8, avatar_path = $9,
+		    updated_at = $10
+		WHERE id = $11
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -157,6 +183,8 @@ func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User)
 		user.Role,
 		user.IsUIICivitas,
 		user.IsApproved,
+		user.EmailVerifiedAt,
+		user.AvatarPath,
 		user.UpdatedAt,
 		user.ID,
 	)
@@ -200,3 +228,297 @@ func (r *postgresUserRepository) UpdateRole(ctx context.Context, userID uuid.UUI
 
 	return nil
 }
+
+// UpdateProfile saves the name and phone number of the user
+func (r *postgresUserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, fullName, phoneNumber string) error {
+	query := `
+		UPDATE users
+		SET full_name = $1, phone_number = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, fullName, phoneNumber, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// UpdatePassword saves a new password hash of the user
+func (r *postgresUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET password_hash = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, passwordHash, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// UpdateAvatar saves the avatar path of the user and returns the path it replaced
+func (r *postgresUserRepository) UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarPath string) (*string, error) {
+	query := `
+		UPDATE users u
+		SET avatar_path = $1, updated_at = $2
+		FROM (SELECT id, avatar_path FROM users WHERE id = $3 FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.avatar_path
+	`
+
+	var oldAvatarPath sql.NullString
+	err := r.db.QueryRowContext(ctx, query, avatarPath, time.Now(), userID).Scan(&oldAvatarPath)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+
+	if !oldAvatarPath.Valid {
+		return nil, nil
+	}
+
+	return &oldAvatarPath.String, nil
+}
+
+// SetSuspension suspends the user since at for reason, nil for both lifts the suspension
+func (r *postgresUserRepository) SetSuspension(ctx context.Context, userID uuid.UUID, at *time.Time, reason *string) error {
+	query := `
+		UPDATE users
+		SET suspended_at = $1, suspension_reason = $2, updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, at, reason, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user suspension: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// SoftDelete marks the user deleted, keeping the row for event history
+func (r *postgresUserRepository) SoftDelete(ctx context.Context, userID uuid.UUID) error {
+	now := time.Now()
+
+	query := `
+		UPDATE users
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepository) MarkEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1
+		WHERE id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to verify user email: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// SetCalendarTokenHash replaces the calendar feed token of the user, nil disables the feed
+func (r *postgresUserRepository) SetCalendarTokenHash(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	query := `
+		UPDATE users
+		SET calendar_token_hash = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tokenHash, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update calendar token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term, so it matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *postgresUserRepository) List(ctx context.Context, filter UserFilter) ([]domain.User, int, error) {
+	where := " WHERE 1=1"
+	var args []interface{}
+	argCount := 1
+
+	if filter.Search != "" {
+		where += fmt.Sprintf(` AND (full_name ILIKE $%d ESCAPE '\' OR email ILIKE $%d ESCAPE '\')`, argCount, argCount)
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+		argCount++
+	}
+
+	if filter.Role != "" {
+		where += fmt.Sprintf(" AND role = $%d", argCount)
+		args = append(args, filter.Role)
+		argCount++
+	}
+
+	switch filter.Status {
+	case UserStatusActive:
+		where += " AND deleted_at IS NULL AND suspended_at IS NULL"
+	case UserStatusSuspended:
+		where += " AND deleted_at IS NULL AND suspended_at IS NOT NULL"
+	case UserStatusDeleted:
+		where += " AND deleted_at IS NOT NULL"
+	default:
+		where += " AND deleted_at IS NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := "SELECT " + userColumns + " FROM users" + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, *user)
+	}
+
+	return users, total, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser scans a row selected with userColumns
+func scanUser(row rowScanner) (*domain.User, error) {
+	var user domain.User
+	var emailVerifiedAt sql.NullTime
+	var avatarPath sql.NullString
+	var suspendedAt sql.NullTime
+	var suspensionReason sql.NullString
+	var deletedAt sql.NullTime
+
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.FullName,
+		&user.PhoneNumber,
+		&user.Role,
+		&user.IsUIICivitas,
+		&user.IsApproved,
+		&emailVerifiedAt,
+		&avatarPath,
+		&suspendedAt,
+		&suspensionReason,
+		&deletedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+
+	if avatarPath.Valid {
+		user.AvatarPath = &avatarPath.String
+	}
+
+	if suspendedAt.Valid {
+		user.SuspendedAt = &suspendedAt.Time
+	}
+
+	if suspensionReason.Valid {
+		user.SuspensionReason = &suspensionReason.String
+	}
+
+	if deletedAt.Valid {
+		user.DeletedAt = &deletedAt.Time
+	}
+
+	return &user, nil
+}
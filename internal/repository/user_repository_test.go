@@ -0,0 +1,151 @@
+package repository_test
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateKeepsConcurrentModeration(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(db)
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+
+	// A profile save read the row before the admin suspended and deleted the user
+	stale, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	now := time.Now()
+	reason := "Spam"
+	if err := repo.SetSuspension(ctx, user.ID, &now, &reason); err != nil {
+		t.Fatalf("SetSuspension: %v", err)
+	}
+	if err := repo.SoftDelete(ctx, user.ID); err != nil {
+		t.Fatalf("SoftDelete: %v", err)
+	}
+
+	stale.FullName = "Renamed User"
+	if err := repo.Update(ctx, stale); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.IsSuspended() || got.SuspensionReason == nil || *got.SuspensionReason != reason {
+		t.Error("suspension was undone by a stale Update")
+	}
+	if !got.IsDeleted() {
+		t.Error("deletion was undone by a stale Update")
+	}
+
+	if err := repo.SoftDelete(ctx, user.ID); err == nil {
+		t.Error("SoftDelete succeeded on a deleted user")
+	}
+}
+
+func TestProfileUpdatesKeepOtherColumns(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(db)
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+
+	if err := repo.UpdateRole(ctx, user.ID, domain.RoleOrganisasi, true); err != nil {
+		t.Fatalf("UpdateRole: %v", err)
+	}
+	if err := repo.UpdateProfile(ctx, user.ID, "Renamed User", "+6281234567891"); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if err := repo.UpdatePassword(ctx, user.ID, "new-hash"); err != nil {
+		t.Fatalf("UpdatePassword: %v", err)
+	}
+
+	old, err := repo.UpdateAvatar(ctx, user.ID, "avatars/first.png")
+	if err != nil {
+		t.Fatalf("UpdateAvatar: %v", err)
+	}
+	if old != nil {
+		t.Errorf("replaced avatar = %q, want none", *old)
+	}
+	old, err = repo.UpdateAvatar(ctx, user.ID, "avatars/second.png")
+	if err != nil {
+		t.Fatalf("UpdateAvatar: %v", err)
+	}
+	if old == nil || *old != "avatars/first.png" {
+		t.Errorf("replaced avatar = %v, want avatars/first.png", old)
+	}
+
+	got, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Role != domain.RoleOrganisasi || !got.IsApproved {
+		t.Errorf("role = %s, approved = %v, want an approved organizer", got.Role, got.IsApproved)
+	}
+	if !got.IsEmailVerified() {
+		t.Error("email verification was lost")
+	}
+	if got.FullName != "Renamed User" || got.PhoneNumber != "+6281234567891" || got.PasswordHash != "new-hash" {
+		t.Errorf("profile = %q, %q, %q, want the updated values", got.FullName, got.PhoneNumber, got.PasswordHash)
+	}
+	if got.AvatarPath == nil || *got.AvatarPath != "avatars/second.png" {
+		t.Errorf("avatar = %v, want avatars/second.png", got.AvatarPath)
+	}
+}
+
+func TestListSearchMatchesWildcardsLiterally(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	repo := repository.NewUserRepository(db)
+
+	// The tag keeps other users of the test database out of the results
+	tag := uuid.NewString()[:8]
+	names := []string{"diskon 100%", "diskon 1000", "nama_user", "namaXuser", `C:\data`, "C:data"}
+	ids := make(map[uuid.UUID]string, len(names))
+	for _, name := range names {
+		user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+		if err := repo.UpdateProfile(ctx, user.ID, tag+" "+name, user.PhoneNumber); err != nil {
+			t.Fatalf("UpdateProfile: %v", err)
+		}
+		ids[user.ID] = name
+	}
+
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"diskon 100%", "diskon 100%"},
+		{"nama_user", "nama_user"},
+		{`C:\data`, `C:\data`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			users, _, err := repo.List(ctx, repository.UserFilter{Search: tag + " " + tt.search, Page: 1, Limit: 100})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+
+			var got []string
+			for _, user := range users {
+				if name, ok := ids[user.ID]; ok {
+					got = append(got, name)
+				}
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("search %q matched %q, want only %q", tt.search, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTokenRepository defines interface for single-use user token data access
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *domain.UserToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.UserToken, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose string) error
+}
+
+type userTokenRepository struct {
+	db DBTX
+}
+
+// NewUserTokenRepository creates a new user token repository
+func NewUserTokenRepository(db DBTX) UserTokenRepository {
+	return &userTokenRepository{
+		db: db,
+	}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	// Generate ID if not set
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	token.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO user_tokens (id, user_id, token_hash, purpose, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.Purpose,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.UserToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, purpose, expires_at, used_at, created_at
+		FROM user_tokens
+		WHERE token_hash = $1
+	`
+
+	var token domain.UserToken
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.Purpose,
+		&token.ExpiresAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Not found is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	// Conditional update so a token can only ever be consumed once
+	query := `
+		UPDATE user_tokens
+		SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark user token as used: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("token has already been used")
+	}
+
+	return nil
+}
+
+func (r *userTokenRepository) InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose string) error {
+	query := `
+		UPDATE user_tokens
+		SET used_at = $1
+		WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), userID, purpose); err != nil {
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+
+	return nil
+}
@@ -21,12 +21,22 @@ type WhitelistRepository interface {
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string, adminNotes string, reviewedBy uuid.UUID) error
 }
 
+// whitelistRequestsWithUsers joins each request to its requester and reviewer
+// so that listings carry their names without a lookup per request
+const whitelistRequestsWithUsers = `whitelist_requests w
+		JOIN users u ON u.id = w.user_id
+		LEFT JOIN users rv ON rv.id = w.reviewed_by`
+
+// whitelistColumns lists the columns scanWhitelistRequest expects, selected FROM whitelistRequestsWithUsers
+const whitelistColumns = `w.id, w.user_id, w.organization_name, w.document_path, w.status, w.admin_notes,
+		       w.submitted_at, w.reviewed_at, w.reviewed_by, u.full_name, u.email, rv.full_name`
+
 type whitelistRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewWhitelistRepository creates a new whitelist repository
-func NewWhitelistRepository(db *sql.DB) WhitelistRepository {
+func NewWhitelistRepository(db DBTX) WhitelistRepository {
 	return &whitelistRepository{
 		db: db,
 	}
@@ -64,30 +74,9 @@ func (r *whitelistRepository) Create(ctx context.Context, request *domain.Whitel
 }
 
 func (r *whitelistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WhitelistRequest, error) {
-	query := `
-		SELECT id, user_id, organization_name, document_path, status, admin_notes, 
-		       submitted_at, reviewed_at, reviewed_by
-		FROM whitelist_requests
-		WHERE id = $1
-	`
-
-	var request domain.WhitelistRequest
-	var reviewedAt sql.NullTime
-	var reviewedBy uuid.NullUUID
-	var adminNotes sql.NullString
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&request.ID,
-		&request.UserID,
-		&request.OrganizationName,
-		&request.DocumentPath,
-		&request.Status,
-		&adminNotes,
-		&request.SubmittedAt,
-		&reviewedAt,
-		&reviewedBy,
-	)
+	query := "SELECT " + whitelistColumns + " FROM " + whitelistRequestsWithUsers + " WHERE w.id = $1"
 
+	request, err := scanWhitelistRequest(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("whitelist request not found")
 	}
@@ -96,47 +85,17 @@ func (r *whitelistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 		return nil, fmt.Errorf("failed to get whitelist request: %w", err)
 	}
 
-	if adminNotes.Valid {
-		s := adminNotes.String
-		request.AdminNotes = &s
-	}
-	if reviewedAt.Valid {
-		request.ReviewedAt = &reviewedAt.Time
-	}
-	if reviewedBy.Valid {
-		request.ReviewedBy = &reviewedBy.UUID
-	}
-
-	return &request, nil
+	return request, nil
 }
 
 func (r *whitelistRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.WhitelistRequest, error) {
-	query := `
-		SELECT id, user_id, organization_name, document_path, status, admin_notes, 
-		       submitted_at, reviewed_at, reviewed_by
-		FROM whitelist_requests
-		WHERE user_id = $1
-		ORDER BY submitted_at DESC
+	query := "SELECT " + whitelistColumns + " FROM " + whitelistRequestsWithUsers + `
+		WHERE w.user_id = $1
+		ORDER BY w.submitted_at DESC
 		LIMIT 1
 	`
 
-	var request domain.WhitelistRequest
-	var reviewedAt sql.NullTime
-	var reviewedBy uuid.NullUUID
-	var adminNotes sql.NullString
-
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&request.ID,
-		&request.UserID,
-		&request.OrganizationName,
-		&request.DocumentPath,
-		&request.Status,
-		&adminNotes,
-		&request.SubmittedAt,
-		&reviewedAt,
-		&reviewedBy,
-	)
-
+	request, err := scanWhitelistRequest(r.db.QueryRowContext(ctx, query, userID))
 	if err == sql.ErrNoRows {
 		return nil, nil // No request found is not an error
 	}
@@ -145,18 +104,7 @@ func (r *whitelistRepository) GetByUserID(ctx context.Context, userID uuid.UUID)
 		return nil, fmt.Errorf("failed to get whitelist request: %w", err)
 	}
 
-	if adminNotes.Valid {
-		s := adminNotes.String
-		request.AdminNotes = &s
-	}
-	if reviewedAt.Valid {
-		request.ReviewedAt = &reviewedAt.Time
-	}
-	if reviewedBy.Valid {
-		request.ReviewedBy = &reviewedBy.UUID
-	}
-
-	return &request, nil
+	return request, nil
 }
 
 func (r *whitelistRepository) GetPendingRequests(ctx context.Context) ([]domain.WhitelistRequest, error) {
@@ -164,26 +112,14 @@ func (r *whitelistRepository) GetPendingRequests(ctx context.Context) ([]domain.
 }
 
 func (r *whitelistRepository) GetAllRequests(ctx context.Context, status string) ([]domain.WhitelistRequest, error) {
-	var query string
+	query := "SELECT " + whitelistColumns + " FROM " + whitelistRequestsWithUsers
 	var args []interface{}
 
-	if status == "" {
-		query = `
-			SELECT id, user_id, organization_name, document_path, status, admin_notes, 
-			       submitted_at, reviewed_at, reviewed_by
-			FROM whitelist_requests
-			ORDER BY submitted_at DESC
-		`
-	} else {
-		query = `
-			SELECT id, user_id, organization_name, document_path, status, admin_notes, 
-			       submitted_at, reviewed_at, reviewed_by
-			FROM whitelist_requests
-			WHERE status = $1
-			ORDER BY submitted_at DESC
-		`
+	if status != "" {
+		query += " WHERE w.status = $1"
 		args = append(args, status)
 	}
+	query += " ORDER BY w.submitted_at DESC"
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -193,39 +129,12 @@ func (r *whitelistRepository) GetAllRequests(ctx context.Context, status string)
 
 	var requests []domain.WhitelistRequest
 	for rows.Next() {
-		var request domain.WhitelistRequest
-		var reviewedAt sql.NullTime
-		var reviewedBy uuid.NullUUID
-		var adminNotes sql.NullString
-
-		err := rows.Scan(
-			&request.ID,
-			&request.UserID,
-			&request.OrganizationName,
-			&request.DocumentPath,
-			&request.Status,
-			&adminNotes,
-			&request.SubmittedAt,
-			&reviewedAt,
-			&reviewedBy,
-		)
-
+		request, err := scanWhitelistRequest(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan whitelist request: %w", err)
 		}
 
-		if adminNotes.Valid {
-			s := adminNotes.String
-			request.AdminNotes = &s
-		}
-		if reviewedAt.Valid {
-			request.ReviewedAt = &reviewedAt.Time
-		}
-		if reviewedBy.Valid {
-			request.ReviewedBy = &reviewedBy.UUID
-		}
-
-		requests = append(requests, request)
+		requests = append(requests, *request)
 	}
 
 	return requests, nil
@@ -290,3 +199,49 @@ func (r *whitelistRepository) UpdateStatus(ctx context.Context, id uuid.UUID, st
 
 	return nil
 }
+
+// scanWhitelistRequest scans a row selected with whitelistColumns
+func scanWhitelistRequest(row rowScanner) (*domain.WhitelistRequest, error) {
+	var request domain.WhitelistRequest
+	var reviewedAt sql.NullTime
+	var reviewedBy uuid.NullUUID
+	var adminNotes, reviewerName sql.NullString
+	var userName, userEmail string
+
+	err := row.Scan(
+		&request.ID,
+		&request.UserID,
+		&request.OrganizationName,
+		&request.DocumentPath,
+		&request.Status,
+		&adminNotes,
+		&request.SubmittedAt,
+		&reviewedAt,
+		&reviewedBy,
+		&userName,
+		&userEmail,
+		&reviewerName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.UserName = &userName
+	request.UserEmail = &userEmail
+	if adminNotes.Valid {
+		s := adminNotes.String
+		request.AdminNotes = &s
+	}
+	if reviewedAt.Valid {
+		request.ReviewedAt = &reviewedAt.Time
+	}
+	if reviewedBy.Valid {
+		request.ReviewedBy = &reviewedBy.UUID
+	}
+	if reviewerName.Valid {
+		s := reviewerName.String
+		request.ReviewerName = &s
+	}
+
+	return &request, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"event-campus-backend/internal/domain"
 	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/usecase"
 	"event-campus-backend/internal/utils"
 	"fmt"
 	"log"
@@ -14,11 +15,14 @@ import (
 
 // Scheduler manages automated tasks
 type Scheduler struct {
-	cron             *cron.Cron
-	eventRepo        repository.EventRepository
-	registrationRepo repository.RegistrationRepository
-	userRepo         repository.UserRepository
-	emailSender      *utils.EmailSender
+	cron                *cron.Cron
+	eventRepo           repository.EventRepository
+	registrationRepo    repository.RegistrationRepository
+	userRepo            repository.UserRepository
+	registrationUsecase usecase.RegistrationUsecase
+	certificateUsecase  usecase.CertificateUsecase
+	emailSender         *utils.EmailSender
+	checkInSecret       string
 }
 
 // NewScheduler creates a new scheduler
@@ -26,14 +30,20 @@ func NewScheduler(
 	eventRepo repository.EventRepository,
 	registrationRepo repository.RegistrationRepository,
 	userRepo repository.UserRepository,
+	registrationUsecase usecase.RegistrationUsecase,
+	certificateUsecase usecase.CertificateUsecase,
 	emailSender *utils.EmailSender,
+	checkInSecret string,
 ) *Scheduler {
 	return &Scheduler{
-		cron:             cron.New(),
-		eventRepo:        eventRepo,
-		registrationRepo: registrationRepo,
-		userRepo:         userRepo,
-		emailSender:      emailSender,
+		cron:                cron.New(),
+		eventRepo:           eventRepo,
+		registrationRepo:    registrationRepo,
+		userRepo:            userRepo,
+		registrationUsecase: registrationUsecase,
+		certificateUsecase:  certificateUsecase,
+		emailSender:         emailSender,
+		checkInSecret:       checkInSecret,
 	}
 }
 
@@ -51,10 +61,24 @@ func (s *Scheduler) Start() error {
 		return fmt.Errorf("failed to add status updater job: %w", err)
 	}
 
+	// Pass on unclaimed waitlist offers every 5 minutes
+	_, err = s.cron.AddFunc("*/5 * * * *", s.ExpireWaitlistOffers)
+	if err != nil {
+		return fmt.Errorf("failed to add waitlist offer expiry job: %w", err)
+	}
+
+	// Issue and email certificates of completed events every 15 minutes
+	_, err = s.cron.AddFunc("*/15 * * * *", s.IssueCertificates)
+	if err != nil {
+		return fmt.Errorf("failed to add certificate job: %w", err)
+	}
+
 	s.cron.Start()
 	log.Println("✅ Scheduler started successfully")
 	log.Println("  - H-1 Reminder: Daily at 09:00 AM")
 	log.Println("  - Event Status Updater: Hourly")
+	log.Println("  - Waitlist Offer Expiry: Every 5 minutes")
+	log.Println("  - Certificates: Every 15 minutes")
 
 	return nil
 }
@@ -71,9 +95,9 @@ func (s *Scheduler) SendH1Reminders() {
 	log.Println("🔔 Running H-1 reminder job...")
 
 	// Get all published events
-	events, err := s.eventRepo.GetAll(ctx, map[string]interface{}{
-		"status": domain.StatusPublished,
-	})
+	events, _, err := s.eventRepo.GetAll(ctx, repository.EventFilter{
+		Status: domain.StatusPublished,
+	}, repository.ListOptions{})
 	if err != nil {
 		log.Printf("Failed to get events for reminders: %v", err)
 		return
@@ -130,6 +154,7 @@ func (s *Scheduler) SendH1Reminders() {
 					location,
 					&zoomLink,
 					reg.ID.String(),
+					utils.GenerateCheckInToken(reg.ID, event.ID, s.checkInSecret),
 				)
 				if err != nil {
 					log.Printf("Failed to send reminder to %s: %v", user.Email, err)
@@ -156,8 +181,7 @@ func (s *Scheduler) UpdateEventStatuses() {
 	log.Println("🔄 Running event status updater...")
 
 	// Get all non-completed/cancelled events
-	filters := map[string]interface{}{}
-	events, err := s.eventRepo.GetAll(ctx, filters)
+	events, _, err := s.eventRepo.GetAll(ctx, repository.EventFilter{}, repository.ListOptions{})
 	if err != nil {
 		log.Printf("Failed to get events: %v", err)
 		return
@@ -198,6 +222,35 @@ func (s *Scheduler) UpdateEventStatuses() {
 	log.Printf("✅ Event statuses updated: %d", updated)
 }
 
+// ExpireWaitlistOffers passes seats whose offer was not claimed in time to the next person on the waitlist
+func (s *Scheduler) ExpireWaitlistOffers() {
+	ctx := context.Background()
+
+	expired, err := s.registrationUsecase.ExpireWaitlistOffers(ctx)
+	if err != nil {
+		log.Printf("Failed to expire waitlist offers: %v", err)
+		return
+	}
+
+	if expired > 0 {
+		log.Printf("✅ Waitlist offers expired: %d", expired)
+	}
+}
+
+// IssueCertificates issues certificates to the attendees of completed events and emails them
+func (s *Scheduler) IssueCertificates() {
+	ctx := context.Background()
+
+	issued, emailed, err := s.certificateUsecase.IssueCertificates(ctx)
+	if err != nil {
+		log.Printf("Failed to issue certificates: %v", err)
+	}
+
+	if issued > 0 || emailed > 0 {
+		log.Printf("✅ Certificates issued: %d, emailed: %d", issued, emailed)
+	}
+}
+
 // RunNow runs specific job immediately (for testing)
 func (s *Scheduler) RunH1RemindersNow() {
 	s.SendH1Reminders()
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/repository"
+	"sync/atomic"
+)
+
+// CountingDB wraps a DBTX and counts the statements sent through it
+type CountingDB struct {
+	db      repository.DBTX
+	queries atomic.Int64
+}
+
+// NewCountingDB creates a CountingDB around db
+func NewCountingDB(db repository.DBTX) *CountingDB {
+	return &CountingDB{db: db}
+}
+
+// Queries returns the number of statements run since the last Reset
+func (c *CountingDB) Queries() int {
+	return int(c.queries.Load())
+}
+
+// Reset sets the statement count back to zero
+func (c *CountingDB) Reset() {
+	c.queries.Store(0)
+}
+
+func (c *CountingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.queries.Add(1)
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+func (c *CountingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.queries.Add(1)
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+func (c *CountingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.queries.Add(1)
+	return c.db.QueryRowContext(ctx, query, args...)
+}
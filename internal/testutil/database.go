@@ -0,0 +1,98 @@
+// Package testutil holds helpers for tests that run against a PostgreSQL database
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+// DB opens the database named by TEST_DATABASE_URL and migrates it once per test binary.
+// Tests are skipped when the variable is not set.
+func DB(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL not set, skipping database test")
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		tb.Fatalf("failed to open test database: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	migrateOnce.Do(func() {
+		migrateErr = repository.RunMigrations(db)
+	})
+	if migrateErr != nil {
+		tb.Fatalf("failed to migrate test database: %v", migrateErr)
+	}
+
+	return db
+}
+
+// CreateUser inserts a verified, approved user with a unique email
+func CreateUser(tb testing.TB, db *sql.DB, role string) *domain.User {
+	tb.Helper()
+
+	now := time.Now()
+	user := &domain.User{
+		Email:           fmt.Sprintf("test-%s@students.uii.ac.id", uuid.NewString()),
+		PasswordHash:    "not-a-real-hash",
+		FullName:        "Test User",
+		PhoneNumber:     "081234567890",
+		Role:            role,
+		IsUIICivitas:    true,
+		IsApproved:      true,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := repository.NewUserRepository(db).Create(context.Background(), user); err != nil {
+		tb.Fatalf("failed to create test user: %v", err)
+	}
+
+	return user
+}
+
+// CreateEvent inserts a published online event open for registration
+func CreateEvent(tb testing.TB, db *sql.DB, organizerID uuid.UUID, maxParticipants int) *domain.Event {
+	tb.Helper()
+
+	zoomLink := "https://zoom.us/j/123456789"
+	start := time.Now().Add(7 * 24 * time.Hour)
+	event := &domain.Event{
+		OrganizerID:          organizerID,
+		Title:                "Test Event",
+		Description:          "Event created by a test",
+		Category:             domain.CategorySeminar,
+		EventType:            domain.EventTypeOnline,
+		ZoomLink:             &zoomLink,
+		StartDate:            start,
+		EndDate:              start.Add(2 * time.Hour),
+		RegistrationDeadline: start.Add(-24 * time.Hour),
+		MaxParticipants:      maxParticipants,
+		Status:               domain.StatusPublished,
+	}
+
+	if err := repository.NewEventRepository(db).Create(context.Background(), event); err != nil {
+		tb.Fatalf("failed to create test event: %v", err)
+	}
+
+	return event
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminUsecase defines interface for admin user management business logic
+type AdminUsecase interface {
+	ListUsers(ctx context.Context, filter repository.UserFilter) ([]response.UserResponse, *response.PaginationMeta, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error)
+	ChangeRole(ctx context.Context, adminID, userID uuid.UUID, role string) (*response.UserResponse, error)
+	SuspendUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*response.UserModerationResponse, error)
+	UnsuspendUser(ctx context.Context, adminID, userID uuid.UUID) (*response.UserResponse, error)
+	RevokeOrganizer(ctx context.Context, adminID, userID uuid.UUID) (*response.UserResponse, error)
+	DeleteUser(ctx context.Context, adminID, userID uuid.UUID) (*response.UserModerationResponse, error)
+	UnlockLogin(ctx context.Context, userID uuid.UUID) error
+}
+
+type adminUsecase struct {
+	userRepo            repository.UserRepository
+	sessionRepo         repository.SessionRepository
+	loginFailureRepo    repository.LoginFailureRepository
+	registrationUsecase RegistrationUsecase
+	baseURL             string
+}
+
+// NewAdminUsecase creates a new admin usecase
+func NewAdminUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	loginFailureRepo repository.LoginFailureRepository,
+	registrationUsecase RegistrationUsecase,
+	baseURL string,
+) AdminUsecase {
+	return &adminUsecase{
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		loginFailureRepo:    loginFailureRepo,
+		registrationUsecase: registrationUsecase,
+		baseURL:             baseURL,
+	}
+}
+
+func (u *adminUsecase) ListUsers(ctx context.Context, filter repository.UserFilter) ([]response.UserResponse, *response.PaginationMeta, error) {
+	users, total, err := u.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	responses := make([]response.UserResponse, len(users))
+	for i := range users {
+		responses[i] = response.ToProfileResponse(&users[i], u.baseURL)
+	}
+
+	return responses, response.NewPaginationMeta(filter.Page, filter.Limit, total, ""), nil
+}
+
+func (u *adminUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *adminUsecase) ChangeRole(ctx context.Context, adminID, userID uuid.UUID, role string) (*response.UserResponse, error) {
+	if !domain.IsValidRole(role) {
+		return nil, fmt.Errorf("invalid role")
+	}
+
+	user, err := u.getManageableUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == role {
+		return nil, fmt.Errorf("user already has role %s", role)
+	}
+
+	// Organizers granted by an admin skip the whitelist review
+	isApproved := role != domain.RoleMahasiswa
+
+	if err := u.userRepo.UpdateRole(ctx, userID, role, isApproved); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	// Access tokens carry the role, force a fresh login
+	if err := u.sessionRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	user.Role = role
+	user.IsApproved = isApproved
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *adminUsecase) SuspendUser(ctx context.Context, adminID, userID uuid.UUID, reason string) (*response.UserModerationResponse, error) {
+	user, err := u.getManageableUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsSuspended() {
+		return nil, fmt.Errorf("user is already suspended")
+	}
+
+	now := time.Now()
+	if err := u.userRepo.SetSuspension(ctx, userID, &now, &reason); err != nil {
+		return nil, fmt.Errorf("failed to suspend user: %w", err)
+	}
+
+	user.SuspendedAt = &now
+	user.SuspensionReason = &reason
+
+	return u.lockOut(ctx, user)
+}
+
+func (u *adminUsecase) UnsuspendUser(ctx context.Context, adminID, userID uuid.UUID) (*response.UserResponse, error) {
+	user, err := u.getManageableUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsSuspended() {
+		return nil, fmt.Errorf("user is not suspended")
+	}
+
+	if err := u.userRepo.SetSuspension(ctx, userID, nil, nil); err != nil {
+		return nil, fmt.Errorf("failed to unsuspend user: %w", err)
+	}
+
+	user.SuspendedAt = nil
+	user.SuspensionReason = nil
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *adminUsecase) RevokeOrganizer(ctx context.Context, adminID, userID uuid.UUID) (*response.UserResponse, error) {
+	user, err := u.getManageableUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsOrganisasi() {
+		return nil, fmt.Errorf("user is not an organizer")
+	}
+
+	if err := u.userRepo.UpdateRole(ctx, userID, domain.RoleMahasiswa, false); err != nil {
+		return nil, fmt.Errorf("failed to revoke organizer status: %w", err)
+	}
+
+	// Access tokens carry the role, force a fresh login
+	if err := u.sessionRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	user.Role = domain.RoleMahasiswa
+	user.IsApproved = false
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *adminUsecase) DeleteUser(ctx context.Context, adminID, userID uuid.UUID) (*response.UserModerationResponse, error) {
+	user, err := u.getManageableUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.SoftDelete(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	now := time.Now()
+	user.DeletedAt = &now
+
+	return u.lockOut(ctx, user)
+}
+
+func (u *adminUsecase) UnlockLogin(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	// Failed logins are tracked by lowercased email, see authUsecase.Login
+	if err := u.loginFailureRepo.Reset(ctx, domain.LoginFailureScopeAccount, strings.ToLower(user.Email)); err != nil {
+		return fmt.Errorf("failed to unlock login: %w", err)
+	}
+
+	return nil
+}
+
+// getManageableUser loads a user an admin is allowed to moderate
+func (u *adminUsecase) getManageableUser(ctx context.Context, adminID, userID uuid.UUID) (*domain.User, error) {
+	if adminID == userID {
+		return nil, fmt.Errorf("you cannot change your own account")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if user.IsDeleted() {
+		return nil, fmt.Errorf("user has been deleted")
+	}
+
+	return user, nil
+}
+
+// lockOut ends every session of a suspended or deleted user and frees their seats in upcoming events
+func (u *adminUsecase) lockOut(ctx context.Context, user *domain.User) (*response.UserModerationResponse, error) {
+	if err := u.sessionRepo.RevokeAllByUser(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	cancelled, err := u.registrationUsecase.CancelUpcomingRegistrations(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel registrations: %w", err)
+	}
+
+	return &response.UserModerationResponse{
+		User:                   response.ToProfileResponse(user, u.baseURL),
+		CancelledRegistrations: cancelled,
+	}, nil
+}
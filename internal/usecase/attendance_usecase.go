@@ -3,17 +3,48 @@ package usecase
 import (
 	"context"
 	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/response"
 	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/utils"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// checkInOpensBefore is how long before the event starts the door check-in opens
+const checkInOpensBefore = time.Hour
+
+// attendanceRemovedRevocationReason is recorded on certificates revoked by RemoveAttendance
+const attendanceRemovedRevocationReason = "Attendance removed"
+
+// attendanceImportMaxRows caps the rows of one attendance import, like bulk marking
+const attendanceImportMaxRows = 1000
+
+// GeoPoint is a device location reported with a self check-in
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
 // AttendanceUsecase defines interface for attendance business logic
 type AttendanceUsecase interface {
 	MarkAttendance(ctx context.Context, organizerID, eventID, userID uuid.UUID, notes *string) error
-	BulkMarkAttendance(ctx context.Context, organizerID, eventID uuid.UUID, userIDs []uuid.UUID) error
+	BulkMarkAttendance(ctx context.Context, organizerID, eventID uuid.UUID, userIDs []string) (*response.BulkAttendanceResponse, error)
+	ImportAttendance(ctx context.Context, organizerID, eventID uuid.UUID, file io.Reader, dryRun bool) (*response.AttendanceImportResponse, error)
+	CheckIn(ctx context.Context, organizerID, eventID uuid.UUID, token string) (*domain.Attendance, error)
+	OpenCheckInWindow(ctx context.Context, organizerID, eventID uuid.UUID, duration time.Duration) (*domain.Event, error)
+	CloseCheckInWindow(ctx context.Context, organizerID, eventID uuid.UUID) error
+	GetSelfCheckInCode(ctx context.Context, organizerID, eventID uuid.UUID) (*response.SelfCheckInCodeResponse, error)
+	SelfCheckIn(ctx context.Context, userID, eventID uuid.UUID, code string, location *GeoPoint) (*domain.Attendance, error)
+	RemoveAttendance(ctx context.Context, organizerID, eventID, userID uuid.UUID) error
+	UpdateAttendanceNotes(ctx context.Context, organizerID, eventID, userID uuid.UUID, notes *string) (*domain.Attendance, error)
+	GetAttendanceHistory(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.AttendanceHistory, error)
 	GetEventAttendance(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.Attendance, error)
+	ExportEventAttendance(ctx context.Context, organizerID, eventID uuid.UUID, fn func(*domain.Registration) error) error
 }
 
 type attendanceUsecase struct {
@@ -21,6 +52,8 @@ type attendanceUsecase struct {
 	eventRepo        repository.EventRepository
 	registrationRepo repository.RegistrationRepository
 	userRepo         repository.UserRepository
+	txManager        repository.TxManager
+	checkInSecret    string
 }
 
 // NewAttendanceUsecase creates a new attendance usecase
@@ -29,12 +62,16 @@ func NewAttendanceUsecase(
 	eventRepo repository.EventRepository,
 	registrationRepo repository.RegistrationRepository,
 	userRepo repository.UserRepository,
+	txManager repository.TxManager,
+	checkInSecret string,
 ) AttendanceUsecase {
 	return &attendanceUsecase{
 		attendanceRepo:   attendanceRepo,
 		eventRepo:        eventRepo,
 		registrationRepo: registrationRepo,
 		userRepo:         userRepo,
+		txManager:        txManager,
+		checkInSecret:    checkInSecret,
 	}
 }
 
@@ -79,98 +116,636 @@ func (u *attendanceUsecase) MarkAttendance(ctx context.Context, organizerID, eve
 		return fmt.Errorf("attendance already marked for this user")
 	}
 
-	// Create attendance record
-	attendance := &domain.Attendance{
-		EventID:        eventID,
-		UserID:         userID,
-		RegistrationID: registration.ID,
-		MarkedBy:       organizerID,
-		Notes:          notes,
+	// Create attendance record and update registration status to attended
+	if _, err := u.checkIn(ctx, registration, organizerID, notes); err != nil {
+		return err
 	}
 
-	if err := u.attendanceRepo.Create(ctx, attendance); err != nil {
-		return fmt.Errorf("failed to mark attendance: %w", err)
+	return nil
+}
+
+// BulkMarkAttendance marks every requested user whose registration is active and
+// reports the outcome per user. All attendances and status updates are applied atomically.
+func (u *attendanceUsecase) BulkMarkAttendance(ctx context.Context, organizerID, eventID uuid.UUID, userIDs []string) (*response.BulkAttendanceResponse, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
 	}
 
-	// Update registration status to attended
-	registration.Status = domain.RegistrationStatusAttended
-	if err := u.registrationRepo.Update(ctx, registration); err != nil {
-		// Log error but don't fail
-		fmt.Printf("Failed to update registration status: %v\n", err)
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to mark attendance for this event")
 	}
 
-	return nil
+	// Check if event has started
+	if !event.HasStarted() {
+		return nil, fmt.Errorf("cannot mark attendance before event starts")
+	}
+
+	results := make([]response.BulkAttendanceResult, len(userIDs))
+	var attendances []domain.Attendance
+	var pending []int // indexes into results of the users to mark
+	seen := make(map[uuid.UUID]bool)
+
+	for i, idStr := range userIDs {
+		results[i].UserID = idStr
+
+		userID, err := uuid.Parse(idStr)
+		if err != nil {
+			results[i].Result = domain.BulkAttendanceInvalidID
+			continue
+		}
+
+		// Listed twice, the first occurrence already takes care of it
+		if seen[userID] {
+			results[i].Result = domain.BulkAttendanceDuplicate
+			continue
+		}
+		seen[userID] = true
+
+		registration, err := u.registrationRepo.GetByUserAndEvent(ctx, userID, eventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get registration: %w", err)
+		}
+
+		switch {
+		case registration == nil || registration.IsCancelled():
+			results[i].Result = domain.BulkAttendanceNotRegistered
+		case registration.IsAttended():
+			results[i].Result = domain.BulkAttendanceAlreadyMarked
+		case registration.IsWaitlist() || registration.IsOffered():
+			results[i].Result = domain.BulkAttendanceWaitlisted
+		default:
+			attendances = append(attendances, domain.Attendance{
+				ID:             uuid.New(),
+				EventID:        eventID,
+				UserID:         userID,
+				RegistrationID: registration.ID,
+				MarkedBy:       organizerID,
+			})
+			pending = append(pending, i)
+		}
+	}
+
+	marked, err := u.markAttendances(ctx, attendances)
+	if err != nil {
+		return nil, err
+	}
+	for j, ok := range marked {
+		if ok {
+			results[pending[j]].Result = domain.BulkAttendanceMarked
+		} else {
+			results[pending[j]].Result = domain.BulkAttendanceAlreadyMarked
+		}
+	}
+
+	report := &response.BulkAttendanceResponse{
+		Summary: make(map[string]int),
+		Results: results,
+	}
+	for _, result := range results {
+		report.Summary[result.Result]++
+	}
+	report.Marked = report.Summary[domain.BulkAttendanceMarked]
+
+	return report, nil
 }
 
-func (u *attendanceUsecase) BulkMarkAttendance(ctx context.Context, organizerID, eventID uuid.UUID, userIDs []uuid.UUID) error {
+// ImportAttendance marks attendance from an uploaded CSV whose rows name participants by
+// email or registration_id, with optional notes. A dry run reports what would be marked
+// without changing anything; otherwise all rows are applied atomically.
+func (u *attendanceUsecase) ImportAttendance(ctx context.Context, organizerID, eventID uuid.UUID, file io.Reader, dryRun bool) (*response.AttendanceImportResponse, error) {
 	// Get event
 	event, err := u.eventRepo.GetByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("event not found")
+		return nil, fmt.Errorf("event not found")
 	}
 
 	// Check ownership
 	if event.OrganizerID != organizerID {
-		return fmt.Errorf("you don't have permission to mark attendance for this event")
+		return nil, fmt.Errorf("you don't have permission to mark attendance for this event")
 	}
 
 	// Check if event has started
 	if !event.HasStarted() {
-		return fmt.Errorf("cannot mark attendance before event starts")
+		return nil, fmt.Errorf("cannot mark attendance before event starts")
 	}
 
-	var attendances []domain.Attendance
-	var registrationsToUpdate []domain.Registration
+	header, rows, err := utils.ReadCSVImport(file, attendanceImportMaxRows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if !slices.Contains(header, "email") && !slices.Contains(header, "registration_id") {
+		return nil, fmt.Errorf("CSV needs an email or registration_id column")
+	}
 
-	for _, userID := range userIDs {
-		// Get registration
-		registration, err := u.registrationRepo.GetByUserAndEvent(ctx, userID, eventID)
-		if err != nil || registration == nil || !registration.IsRegistered() {
-			// Skip invalid registrations
+	// Index the event's registrations once instead of looking up every row
+	byID := make(map[uuid.UUID]*domain.Registration)
+	byEmail := make(map[string]*domain.Registration)
+	err = u.registrationRepo.StreamByEvent(ctx, eventID, false, func(registration *domain.Registration) error {
+		byID[registration.ID] = registration
+		byEmail[strings.ToLower(*registration.UserEmail)] = registration
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registrations: %w", err)
+	}
+
+	results := make([]response.AttendanceImportRow, len(rows))
+	var attendances []domain.Attendance
+	var pending []int // indexes into results of the rows to mark
+	seen := make(map[uuid.UUID]bool)
+
+	for i, row := range rows {
+		result := &results[i]
+		result.Line = row.Line
+		result.Email = row.Values["email"]
+		result.RegistrationID = row.Values["registration_id"]
+
+		var registration *domain.Registration
+		switch {
+		case result.RegistrationID != "":
+			id, err := uuid.Parse(result.RegistrationID)
+			if err != nil {
+				result.Result = domain.BulkAttendanceInvalidID
+				result.Error = "registration_id is not a valid ID"
+				continue
+			}
+			registration = byID[id]
+		case result.Email != "":
+			registration = byEmail[strings.ToLower(result.Email)]
+		default:
+			result.Result = domain.BulkAttendanceInvalidID
+			result.Error = "row has no email or registration_id"
 			continue
 		}
 
-		// Check if already marked
-		existingAttendance, _ := u.attendanceRepo.GetByEventAndUser(ctx, eventID, userID)
-		if existingAttendance != nil {
-			// Skip already marked
-			continue
+		if registration != nil {
+			result.UserName = *registration.UserName
+			result.Email = *registration.UserEmail
 		}
 
-		// Add to batch
-		attendance := domain.Attendance{
-			ID:             uuid.New(),
-			EventID:        eventID,
-			UserID:         userID,
-			RegistrationID: registration.ID,
-			MarkedBy:       organizerID,
+		switch {
+		case registration == nil || registration.IsCancelled():
+			result.Result = domain.BulkAttendanceNotRegistered
+		case seen[registration.ID]:
+			// Listed twice, the first occurrence already takes care of it
+			result.Result = domain.BulkAttendanceDuplicate
+		case registration.IsAttended():
+			result.Result = domain.BulkAttendanceAlreadyMarked
+		case registration.IsWaitlist() || registration.IsOffered():
+			result.Result = domain.BulkAttendanceWaitlisted
+		default:
+			var notes *string
+			if note := row.Values["notes"]; note != "" {
+				notes = &note
+			}
+
+			attendances = append(attendances, domain.Attendance{
+				ID:             uuid.New(),
+				EventID:        eventID,
+				UserID:         registration.UserID,
+				RegistrationID: registration.ID,
+				MarkedBy:       organizerID,
+				Notes:          notes,
+			})
+			pending = append(pending, i)
+			result.Result = domain.BulkAttendanceWillMark
 		}
-		attendances = append(attendances, attendance)
 
-		// Update registration status
-		registration.Status = domain.RegistrationStatusAttended
-		registrationsToUpdate = append(registrationsToUpdate, *registration)
+		if registration != nil {
+			seen[registration.ID] = true
+		}
 	}
 
-	if len(attendances) == 0 {
-		return fmt.Errorf("no valid attendances to mark")
+	if !dryRun {
+		marked, err := u.markAttendances(ctx, attendances)
+		if err != nil {
+			return nil, err
+		}
+		for j, ok := range marked {
+			if ok {
+				results[pending[j]].Result = domain.BulkAttendanceMarked
+			} else {
+				results[pending[j]].Result = domain.BulkAttendanceAlreadyMarked
+			}
+		}
 	}
 
-	// Bulk create attendances
-	if err := u.attendanceRepo.BulkCreate(ctx, attendances); err != nil {
-		return fmt.Errorf("failed to bulk mark attendance: %w", err)
+	report := &response.AttendanceImportResponse{
+		DryRun:  dryRun,
+		Summary: make(map[string]int),
+		Rows:    results,
+	}
+	for _, result := range results {
+		report.Summary[result.Result]++
 	}
+	report.Marked = report.Summary[domain.BulkAttendanceMarked]
 
-	// Update registration statuses
-	for _, reg := range registrationsToUpdate {
-		if err := u.registrationRepo.Update(ctx, &reg); err != nil {
-			fmt.Printf("Failed to update registration status for user %s: %v\n", reg.UserID, err)
+	return report, nil
+}
+
+// markAttendances creates the given attendances in one transaction and reports which were
+// marked. Claiming each registration first keeps concurrent check-ins from marking it twice;
+// those lost to one are reported false.
+func (u *attendanceUsecase) markAttendances(ctx context.Context, attendances []domain.Attendance) ([]bool, error) {
+	marked := make([]bool, len(attendances))
+
+	err := u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var toCreate []domain.Attendance
+		for i, attendance := range attendances {
+			ok, err := repos.Registrations.MarkAttended(ctx, attendance.RegistrationID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			marked[i] = true
+			toCreate = append(toCreate, attendance)
+		}
+
+		if err := repos.Attendances.BulkCreate(ctx, toCreate); err != nil {
+			return fmt.Errorf("failed to bulk mark attendance: %w", err)
 		}
+
+		history := make([]domain.AttendanceHistory, len(toCreate))
+		for i, attendance := range toCreate {
+			history[i] = markedHistory(&attendance)
+		}
+
+		return repos.Attendances.CreateHistory(ctx, history)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marked, nil
+}
+
+// CheckIn marks attendance from the signed token of a registration's QR code
+func (u *attendanceUsecase) CheckIn(ctx context.Context, organizerID, eventID uuid.UUID, token string) (*domain.Attendance, error) {
+	registrationID, tokenEventID, err := utils.ValidateCheckInToken(token, u.checkInSecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check-in code")
+	}
+
+	if tokenEventID != eventID {
+		return nil, fmt.Errorf("check-in code is for a different event")
+	}
+
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to check in participants for this event")
+	}
+
+	opensAt := event.StartDate.Add(-checkInOpensBefore)
+	if time.Now().Before(opensAt) {
+		return nil, fmt.Errorf("check-in is not open yet, it opens at %s", opensAt.Format("02 Jan 2006 15:04"))
+	}
+
+	// Get registration
+	registration, err := u.registrationRepo.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	if registration.EventID != eventID {
+		return nil, fmt.Errorf("check-in code is for a different event")
+	}
+
+	if registration.IsAttended() {
+		return nil, fmt.Errorf("participant has already checked in")
+	}
+
+	if !registration.IsRegistered() {
+		return nil, fmt.Errorf("registration is not active")
+	}
+
+	attendance, err := u.checkIn(ctx, registration, organizerID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if user, err := u.userRepo.GetByID(ctx, registration.UserID); err == nil {
+		attendance.UserName = &user.FullName
+		attendance.UserEmail = &user.Email
+	}
+	attendance.EventTitle = &event.Title
+
+	return attendance, nil
+}
+
+// OpenCheckInWindow lets registered participants check themselves in for duration,
+// cut off at the end of the event
+func (u *attendanceUsecase) OpenCheckInWindow(ctx context.Context, organizerID, eventID uuid.UUID, duration time.Duration) (*domain.Event, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to open check-in for this event")
+	}
+
+	if event.Status == domain.StatusCancelled {
+		return nil, fmt.Errorf("event is cancelled")
+	}
+
+	if !event.HasStarted() || event.HasEnded() {
+		return nil, fmt.Errorf("self check-in can only be opened while the event is running")
+	}
+
+	opensAt := time.Now()
+	closesAt := opensAt.Add(duration)
+	if closesAt.After(event.EndDate) {
+		closesAt = event.EndDate
+	}
+
+	if err := u.eventRepo.SetCheckInWindow(ctx, eventID, &opensAt, &closesAt); err != nil {
+		return nil, fmt.Errorf("failed to open check-in window: %w", err)
+	}
+
+	event.CheckInOpensAt = &opensAt
+	event.CheckInClosesAt = &closesAt
+
+	return event, nil
+}
+
+func (u *attendanceUsecase) CloseCheckInWindow(ctx context.Context, organizerID, eventID uuid.UUID) error {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return fmt.Errorf("you don't have permission to close check-in for this event")
+	}
+
+	if err := u.eventRepo.SetCheckInWindow(ctx, eventID, nil, nil); err != nil {
+		return fmt.Errorf("failed to close check-in window: %w", err)
 	}
 
 	return nil
 }
 
+// GetSelfCheckInCode returns the code the organizer displays during the self check-in window
+func (u *attendanceUsecase) GetSelfCheckInCode(ctx context.Context, organizerID, eventID uuid.UUID) (*response.SelfCheckInCodeResponse, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to view the check-in code for this event")
+	}
+
+	if !event.IsSelfCheckInOpen() {
+		return nil, fmt.Errorf("self check-in is not open")
+	}
+
+	now := time.Now()
+	return &response.SelfCheckInCodeResponse{
+		Code:           utils.GenerateEventCheckInCode(eventID, u.checkInSecret, now),
+		ValidUntil:     now.Truncate(utils.EventCheckInCodePeriod).Add(utils.EventCheckInCodePeriod),
+		WindowClosesAt: *event.CheckInClosesAt,
+	}, nil
+}
+
+// SelfCheckIn marks the attendance of a registered participant who submits the displayed code.
+// Events with a geofence also require the device location to be within its radius.
+func (u *attendanceUsecase) SelfCheckIn(ctx context.Context, userID, eventID uuid.UUID, code string, location *GeoPoint) (*domain.Attendance, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	if !event.HasStarted() || event.HasEnded() || !event.IsSelfCheckInOpen() {
+		return nil, fmt.Errorf("self check-in is not open")
+	}
+
+	if !utils.ValidateEventCheckInCode(code, eventID, u.checkInSecret, time.Now()) {
+		return nil, fmt.Errorf("invalid or expired check-in code")
+	}
+
+	var notes *string
+	if event.HasGeofence() {
+		if location == nil {
+			return nil, fmt.Errorf("your location is required to check in to this event")
+		}
+
+		distance := utils.DistanceMeters(*event.Latitude, *event.Longitude, location.Latitude, location.Longitude)
+		if distance > float64(*event.CheckInRadiusMeters) {
+			return nil, fmt.Errorf("you are %.0f m from the event location, check-in is only allowed within %d m", distance, *event.CheckInRadiusMeters)
+		}
+
+		note := fmt.Sprintf("Self check-in %.0f m from event location (%.6f, %.6f)", distance, location.Latitude, location.Longitude)
+		notes = &note
+	}
+
+	// Get registration
+	registration, err := u.registrationRepo.GetByUserAndEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration: %w", err)
+	}
+
+	if registration == nil {
+		return nil, fmt.Errorf("you are not registered for this event")
+	}
+
+	if registration.IsAttended() {
+		return nil, fmt.Errorf("you have already checked in")
+	}
+
+	if !registration.IsRegistered() {
+		return nil, fmt.Errorf("your registration is not active")
+	}
+
+	attendance, err := u.checkIn(ctx, registration, userID, notes)
+	if err != nil {
+		return nil, err
+	}
+	attendance.EventTitle = &event.Title
+
+	return attendance, nil
+}
+
+// checkIn records the attendance of a registered registration. Claiming the
+// registration first makes a second check-in fail even when both run at once.
+func (u *attendanceUsecase) checkIn(ctx context.Context, registration *domain.Registration, markedBy uuid.UUID, notes *string) (*domain.Attendance, error) {
+	attendance := &domain.Attendance{
+		EventID:        registration.EventID,
+		UserID:         registration.UserID,
+		RegistrationID: registration.ID,
+		MarkedBy:       markedBy,
+		Notes:          notes,
+	}
+
+	err := u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		marked, err := repos.Registrations.MarkAttended(ctx, registration.ID)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			return fmt.Errorf("participant has already checked in")
+		}
+
+		if err := repos.Attendances.Create(ctx, attendance); err != nil {
+			return fmt.Errorf("failed to mark attendance: %w", err)
+		}
+
+		return repos.Attendances.CreateHistory(ctx, []domain.AttendanceHistory{markedHistory(attendance)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+// markedHistory is the history entry recording that an attendance was marked
+func markedHistory(attendance *domain.Attendance) domain.AttendanceHistory {
+	return domain.AttendanceHistory{
+		AttendanceID:   attendance.ID,
+		RegistrationID: attendance.RegistrationID,
+		Action:         domain.AttendanceActionMarked,
+		NewNotes:       attendance.Notes,
+		ChangedBy:      attendance.MarkedBy,
+		ChangedAt:      attendance.MarkedAt,
+	}
+}
+
+// RemoveAttendance undoes a mistaken attendance mark and restores the registration to registered
+func (u *attendanceUsecase) RemoveAttendance(ctx context.Context, organizerID, eventID, userID uuid.UUID) error {
+	attendance, err := u.getOwnedAttendance(ctx, organizerID, eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		restored, err := repos.Registrations.UnmarkAttended(ctx, attendance.RegistrationID)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return fmt.Errorf("attendance has already been removed")
+		}
+
+		// A certificate already issued no longer proves attendance
+		if _, err := repos.Certificates.RevokeByRegistration(ctx, attendance.RegistrationID, organizerID, attendanceRemovedRevocationReason); err != nil {
+			return err
+		}
+
+		if err := repos.Attendances.Delete(ctx, attendance.ID); err != nil {
+			return err
+		}
+
+		return repos.Attendances.CreateHistory(ctx, []domain.AttendanceHistory{{
+			AttendanceID:   attendance.ID,
+			RegistrationID: attendance.RegistrationID,
+			Action:         domain.AttendanceActionRemoved,
+			OldNotes:       attendance.Notes,
+			ChangedBy:      organizerID,
+		}})
+	})
+}
+
+// UpdateAttendanceNotes replaces the notes of an attendance, nil clears them
+func (u *attendanceUsecase) UpdateAttendanceNotes(ctx context.Context, organizerID, eventID, userID uuid.UUID, notes *string) (*domain.Attendance, error) {
+	attendance, err := u.getOwnedAttendance(ctx, organizerID, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := domain.AttendanceHistory{
+		AttendanceID:   attendance.ID,
+		RegistrationID: attendance.RegistrationID,
+		Action:         domain.AttendanceActionNotesUpdated,
+		OldNotes:       attendance.Notes,
+		NewNotes:       notes,
+		ChangedBy:      organizerID,
+	}
+	attendance.Notes = notes
+
+	err = u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if err := repos.Attendances.Update(ctx, attendance); err != nil {
+			return err
+		}
+
+		return repos.Attendances.CreateHistory(ctx, []domain.AttendanceHistory{history})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+// GetAttendanceHistory lists every attendance change of an event, newest first
+func (u *attendanceUsecase) GetAttendanceHistory(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.AttendanceHistory, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to view attendance for this event")
+	}
+
+	history, err := u.attendanceRepo.GetHistoryByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance history: %w", err)
+	}
+
+	return history, nil
+}
+
+// getOwnedAttendance gets the attendance of a user at an event the organizer may correct
+func (u *attendanceUsecase) getOwnedAttendance(ctx context.Context, organizerID, eventID, userID uuid.UUID) (*domain.Attendance, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to change attendance for this event")
+	}
+
+	if event.Status == domain.StatusCancelled {
+		return nil, fmt.Errorf("event is cancelled")
+	}
+
+	attendance, err := u.attendanceRepo.GetByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+
+	if attendance == nil {
+		return nil, fmt.Errorf("attendance not found for this user")
+	}
+
+	return attendance, nil
+}
+
 func (u *attendanceUsecase) GetEventAttendance(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.Attendance, error) {
 	// Get event
 	event, err := u.eventRepo.GetByID(ctx, eventID)
@@ -190,3 +765,23 @@ func (u *attendanceUsecase) GetEventAttendance(ctx context.Context, organizerID,
 
 	return attendances, nil
 }
+
+// ExportEventAttendance streams the registrations that attended an event to fn, in registration order
+func (u *attendanceUsecase) ExportEventAttendance(ctx context.Context, organizerID, eventID uuid.UUID, fn func(*domain.Registration) error) error {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return fmt.Errorf("you don't have permission to view attendance for this event")
+	}
+
+	if err := u.registrationRepo.StreamByEvent(ctx, eventID, true, fn); err != nil {
+		return fmt.Errorf("failed to export attendance: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"testing"
+	"time"
+)
+
+func TestBulkMarkAttendanceReportsDuplicates(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+
+	registrations := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+	uc := NewAttendanceUsecase(
+		repository.NewAttendanceRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewRegistrationRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTxManager(db),
+		"test-secret",
+	)
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 10)
+	registration := registerUser(t, db, registrations, event.ID)
+
+	// Attendance can only be marked once the event has started
+	if _, err := db.ExecContext(ctx, "UPDATE events SET start_date = $1 WHERE id = $2", time.Now().Add(-time.Hour), event.ID); err != nil {
+		t.Fatalf("failed to start event: %v", err)
+	}
+
+	userID := registration.UserID.String()
+	report, err := uc.BulkMarkAttendance(ctx, organizer.ID, event.ID, []string{userID, userID})
+	if err != nil {
+		t.Fatalf("BulkMarkAttendance: %v", err)
+	}
+
+	want := []string{domain.BulkAttendanceMarked, domain.BulkAttendanceDuplicate}
+	for i, result := range report.Results {
+		if result.Result != want[i] {
+			t.Errorf("result %d = %s, want %s", i, result.Result, want[i])
+		}
+	}
+	if report.Marked != 1 {
+		t.Errorf("marked = %d, want 1", report.Marked)
+	}
+
+	// Marking again reports the user as already marked, not as a duplicate
+	report, err = uc.BulkMarkAttendance(ctx, organizer.ID, event.ID, []string{userID})
+	if err != nil {
+		t.Fatalf("BulkMarkAttendance: %v", err)
+	}
+	if got := report.Results[0].Result; got != domain.BulkAttendanceAlreadyMarked {
+		t.Errorf("result = %s, want %s", got, domain.BulkAttendanceAlreadyMarked)
+	}
+}
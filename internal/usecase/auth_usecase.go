@@ -8,33 +8,92 @@ import (
 	"event-campus-backend/internal/repository"
 	"event-campus-backend/internal/utils"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// refreshTokenBytes is the amount of entropy in a refresh token
+const refreshTokenBytes = 32
+
+// userTokenBytes is the amount of entropy in emailed verification and reset tokens
+const userTokenBytes = 32
+
+// LoginPolicy configures failed login backoff and lockout
+type LoginPolicy struct {
+	MaxAttempts     int           // failures per account before lockout
+	IPMaxAttempts   int           // failures per client IP before lockout
+	BackoffBase     time.Duration // wait after the first failure, doubled on every further failure
+	LockoutDuration time.Duration
+}
+
 // AuthUsecase defines interface for authentication business logic
 type AuthUsecase interface {
-	Register(ctx context.Context, req *request.RegisterRequest) (*response.LoginResponse, error)
-	Login(ctx context.Context, req *request.LoginRequest) (*response.LoginResponse, error)
+	Register(ctx context.Context, req *request.RegisterRequest, userAgent, ipAddress string) (*response.LoginResponse, error)
+	Login(ctx context.Context, req *request.LoginRequest, userAgent, ipAddress string) (*response.LoginResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*response.LoginResponse, error)
+	Logout(ctx context.Context, sessionID uuid.UUID) error
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	ValidateSession(ctx context.Context, sessionID, userID uuid.UUID) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
 }
 
 type authUsecase struct {
-	userRepo      repository.UserRepository
-	jwtSecret     string
-	jwtExpiration time.Duration
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	userTokenRepo     repository.UserTokenRepository
+	loginFailureRepo  repository.LoginFailureRepository
+	emailSender       *utils.EmailSender
+	jwtSecret         string
+	jwtExpiration     time.Duration
+	refreshExpiration time.Duration
+	verifyExpiration  time.Duration
+	resetExpiration   time.Duration
+	baseURL           string
+	frontendURL       string
+	loginPolicy       LoginPolicy
 }
 
 // NewAuthUsecase creates a new authentication usecase
-func NewAuthUsecase(userRepo repository.UserRepository, jwtSecret string, jwtExpiration time.Duration) AuthUsecase {
+func NewAuthUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	userTokenRepo repository.UserTokenRepository,
+	loginFailureRepo repository.LoginFailureRepository,
+	emailSender *utils.EmailSender,
+	jwtSecret string,
+	jwtExpiration time.Duration,
+	refreshExpiration time.Duration,
+	verifyExpiration time.Duration,
+	resetExpiration time.Duration,
+	baseURL string,
+	frontendURL string,
+	loginPolicy LoginPolicy,
+) AuthUsecase {
 	return &authUsecase{
-		userRepo:      userRepo,
-		jwtSecret:     jwtSecret,
-		jwtExpiration: jwtExpiration,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		userTokenRepo:     userTokenRepo,
+		loginFailureRepo:  loginFailureRepo,
+		emailSender:       emailSender,
+		jwtSecret:         jwtSecret,
+		jwtExpiration:     jwtExpiration,
+		refreshExpiration: refreshExpiration,
+		verifyExpiration:  verifyExpiration,
+		resetExpiration:   resetExpiration,
+		baseURL:           baseURL,
+		frontendURL:       frontendURL,
+		loginPolicy:       loginPolicy,
 	}
 }
 
-func (u *authUsecase) Register(ctx context.Context, req *request.RegisterRequest) (*response.LoginResponse, error) {
+func (u *authUsecase) Register(ctx context.Context, req *request.RegisterRequest, userAgent, ipAddress string) (*response.LoginResponse, error) {
 	// Validate email format
 	if !utils.IsValidEmail(req.Email) {
 		return nil, fmt.Errorf("invalid email format")
@@ -78,40 +137,426 @@ func (u *authUsecase) Register(ctx context.Context, req *request.RegisterRequest
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, u.jwtSecret, u.jwtExpiration)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+	// Send verification email
+	if err := u.sendVerificationEmail(ctx, user); err != nil {
+		// Log error but don't fail, user can request a new link
+		fmt.Printf("Failed to send verification email: %v\n", err)
 	}
 
-	// Return response
-	return &response.LoginResponse{
-		Token: token,
-		User:  response.ToUserResponse(user),
-	}, nil
+	return u.startSession(ctx, user, userAgent, ipAddress)
 }
 
-func (u *authUsecase) Login(ctx context.Context, req *request.LoginRequest) (*response.LoginResponse, error) {
+func (u *authUsecase) Login(ctx context.Context, req *request.LoginRequest, userAgent, ipAddress string) (*response.LoginResponse, error) {
+	accountKey := strings.ToLower(req.Email)
+
+	// Reject before touching bcrypt while the account or IP is backing off
+	if err := u.checkLoginAllowed(ctx, accountKey, ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Get user by email
 	user, err := u.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
+		// Unknown emails are throttled too, so they look the same as wrong passwords
+		u.recordLoginFailure(ctx, nil, accountKey, ipAddress)
 		return nil, fmt.Errorf("invalid email or password")
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		u.recordLoginFailure(ctx, user, accountKey, ipAddress)
 		return nil, fmt.Errorf("invalid email or password")
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, u.jwtSecret, u.jwtExpiration)
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	if err := u.loginFailureRepo.Reset(ctx, domain.LoginFailureScopeAccount, accountKey); err != nil {
+		fmt.Printf("Failed to reset login failures: %v\n", err)
+	}
+
+	return u.startSession(ctx, user, userAgent, ipAddress)
+}
+
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*response.LoginResponse, error) {
+	tokenHash := utils.HashToken(refreshToken)
+
+	session, err := u.sessionRepo.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session == nil {
+		// A rotated-out token being presented again means it was copied;
+		// kill the whole session so neither copy can be used any more
+		reused, err := u.sessionRepo.GetByPreviousTokenHash(ctx, tokenHash)
+		if err == nil && reused != nil {
+			if err := u.sessionRepo.Revoke(ctx, reused.ID); err != nil {
+				fmt.Printf("Failed to revoke reused session: %v\n", err)
+			}
+		}
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
+	if !session.IsActive() {
+		return nil, fmt.Errorf("session has expired or been revoked")
+	}
+
+	// Reload user so role changes are picked up on refresh
+	user, err := u.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	// Rotate refresh token
+	newRefreshToken, err := utils.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.refreshExpiration)
+	if err := u.sessionRepo.Rotate(ctx, session.ID, tokenHash, utils.HashToken(newRefreshToken), expiresAt); err != nil {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+	session.ExpiresAt = expiresAt
+
+	return u.issueTokens(user, session, newRefreshToken)
+}
+
+func (u *authUsecase) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	if err := u.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+
+	return nil
+}
+
+func (u *authUsecase) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	if err := u.sessionRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to logout from all sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (u *authUsecase) ValidateSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	session, err := u.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	if session.UserID != userID {
+		return fmt.Errorf("session does not belong to user")
+	}
+
+	if !session.IsActive() {
+		return fmt.Errorf("session has expired or been revoked")
+	}
+
+	// Suspension revokes sessions too, this also covers tokens issued in between
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	return checkAccountActive(user)
+}
+
+func (u *authUsecase) ForgotPassword(ctx context.Context, email string) error {
+	// Unknown emails are silently accepted so the endpoint cannot be used
+	// to find out which addresses have an account
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil || user.IsDeleted() {
+		return nil
+	}
+
+	// Only the most recent reset link should work
+	if err := u.userTokenRepo.InvalidateByUser(ctx, user.ID, domain.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	token, err := u.createUserToken(ctx, user.ID, domain.TokenPurposePasswordReset, u.resetExpiration)
+	if err != nil {
+		return err
+	}
+
+	if u.emailSender != nil {
+		resetURL := fmt.Sprintf("%s/reset-password?token=%s", u.frontendURL, url.QueryEscape(token))
+		if err := u.emailSender.SendPasswordReset(user.Email, user.FullName, resetURL, u.resetExpiration); err != nil {
+			// Log error but don't fail, the response must look the same for every email
+			fmt.Printf("Failed to send password reset email: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *authUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userToken, err := u.consumeUserToken(ctx, token, domain.TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userToken.UserID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	// Hash password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := u.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Receiving the reset link proves ownership of the address
+	if err := u.userRepo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	// Sign out everywhere, whoever knew the old password must not stay logged in
+	if err := u.sessionRepo.RevokeAllByUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// The owner proved access to the mailbox, lift any failed login lockout
+	if err := u.loginFailureRepo.Reset(ctx, domain.LoginFailureScopeAccount, strings.ToLower(user.Email)); err != nil {
+		fmt.Printf("Failed to reset login failures: %v\n", err)
+	}
+
+	return nil
+}
+
+func (u *authUsecase) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := u.consumeUserToken(ctx, token, domain.TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.MarkEmailVerified(ctx, userToken.UserID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return nil
+}
+
+func (u *authUsecase) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	if user.IsEmailVerified() {
+		return fmt.Errorf("email is already verified")
+	}
+
+	return u.sendVerificationEmail(ctx, user)
+}
+
+// checkLoginAllowed returns a too many requests error while the account or IP is locked
+func (u *authUsecase) checkLoginAllowed(ctx context.Context, accountKey, ipAddress string) error {
+	var retryAfter time.Duration
+
+	scopes := map[string]string{domain.LoginFailureScopeAccount: accountKey}
+	if ipAddress != "" {
+		scopes[domain.LoginFailureScopeIP] = ipAddress
+	}
+
+	for scope, key := range scopes {
+		failure, err := u.loginFailureRepo.Get(ctx, scope, key)
+		if err != nil {
+			return err
+		}
+		if failure != nil && failure.RetryAfter() > retryAfter {
+			retryAfter = failure.RetryAfter()
+		}
+	}
+
+	if retryAfter > 0 {
+		return utils.NewTooManyRequestsError(
+			fmt.Sprintf("too many failed login attempts, try again in %s", retryAfter.Round(time.Second)),
+			retryAfter,
+		)
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login for the account and the IP and
+// locks them for an exponentially growing delay, or the full lockout once the
+// limit is reached. Errors are only logged, the login already failed.
+func (u *authUsecase) recordLoginFailure(ctx context.Context, user *domain.User, accountKey, ipAddress string) {
+	count, err := u.lockAfterFailure(ctx, domain.LoginFailureScopeAccount, accountKey, u.loginPolicy.MaxAttempts)
+	if err != nil {
+		fmt.Printf("Failed to record login failure: %v\n", err)
+	} else if count == u.loginPolicy.MaxAttempts && user != nil && u.emailSender != nil {
+		// Notify only on the failure that triggers the lockout
+		lockedUntil := time.Now().Add(u.loginPolicy.LockoutDuration)
+		if err := u.emailSender.SendAccountLockedNotification(user.Email, user.FullName, count, lockedUntil, ipAddress); err != nil {
+			fmt.Printf("Failed to send account locked email: %v\n", err)
+		}
+	}
+
+	if ipAddress != "" {
+		if _, err := u.lockAfterFailure(ctx, domain.LoginFailureScopeIP, ipAddress, u.loginPolicy.IPMaxAttempts); err != nil {
+			fmt.Printf("Failed to record login failure: %v\n", err)
+		}
+	}
+}
+
+// lockAfterFailure records one failure for scope and key and applies the backoff
+func (u *authUsecase) lockAfterFailure(ctx context.Context, scope, key string, maxAttempts int) (int, error) {
+	count, err := u.loginFailureRepo.RecordFailure(ctx, scope, key, u.loginPolicy.LockoutDuration)
+	if err != nil {
+		return 0, err
+	}
+
+	delay := u.loginPolicy.LockoutDuration
+	if count < maxAttempts {
+		delay = u.loginPolicy.BackoffBase << (count - 1)
+		if delay <= 0 || delay > u.loginPolicy.LockoutDuration {
+			delay = u.loginPolicy.LockoutDuration
+		}
+	}
+
+	if err := u.loginFailureRepo.Lock(ctx, scope, key, time.Now().Add(delay)); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// checkAccountActive rejects suspended and deleted accounts
+func checkAccountActive(user *domain.User) error {
+	if user.IsDeleted() {
+		return fmt.Errorf("account has been deleted")
+	}
+
+	if user.IsSuspended() {
+		return fmt.Errorf("account has been suspended")
+	}
+
+	return nil
+}
+
+// sendVerificationEmail issues a fresh verification token and emails its link
+func (u *authUsecase) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	// Only the most recent verification link should work
+	if err := u.userTokenRepo.InvalidateByUser(ctx, user.ID, domain.TokenPurposeEmailVerification); err != nil {
+		return err
+	}
+
+	token, err := u.createUserToken(ctx, user.ID, domain.TokenPurposeEmailVerification, u.verifyExpiration)
+	if err != nil {
+		return err
+	}
+
+	if u.emailSender == nil {
+		return nil
+	}
+
+	verifyURL := fmt.Sprintf("%s/api/v1/auth/verify-email?token=%s", u.baseURL, url.QueryEscape(token))
+	if err := u.emailSender.SendEmailVerification(user.Email, user.FullName, verifyURL, u.verifyExpiration); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// createUserToken stores the hash of a new single-use token and returns the raw token
+func (u *authUsecase) createUserToken(ctx context.Context, userID uuid.UUID, purpose string, expiration time.Duration) (string, error) {
+	token, err := utils.GenerateRandomToken(userTokenBytes)
+	if err != nil {
+		return "", err
+	}
+
+	userToken := &domain.UserToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(token),
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(expiration),
+	}
+
+	if err := u.userTokenRepo.Create(ctx, userToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeUserToken validates a single-use token for the given purpose and marks it used
+func (u *authUsecase) consumeUserToken(ctx context.Context, token, purpose string) (*domain.UserToken, error) {
+	userToken, err := u.userTokenRepo.GetByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if userToken == nil || userToken.Purpose != purpose {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	if userToken.IsUsed() {
+		return nil, fmt.Errorf("token has already been used")
+	}
+
+	if userToken.IsExpired() {
+		return nil, fmt.Errorf("token has expired")
+	}
+
+	// Marking is conditional, so of two concurrent requests only one wins
+	if err := u.userTokenRepo.MarkUsed(ctx, userToken.ID); err != nil {
+		return nil, err
+	}
+
+	return userToken, nil
+}
+
+// startSession creates a new session for the user and issues its first token pair
+func (u *authUsecase) startSession(ctx context.Context, user *domain.User, userAgent, ipAddress string) (*response.LoginResponse, error) {
+	refreshToken, err := utils.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		ExpiresAt:        time.Now().Add(u.refreshExpiration),
+	}
+	if userAgent != "" {
+		session.UserAgent = &userAgent
+	}
+	if ipAddress != "" {
+		session.IPAddress = &ipAddress
+	}
+
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return u.issueTokens(user, session, refreshToken)
+}
+
+// issueTokens signs an access token for the session and builds the login response
+func (u *authUsecase) issueTokens(user *domain.User, session *domain.Session, refreshToken string) (*response.LoginResponse, error) {
+	token, err := utils.GenerateToken(user.ID, session.ID, user.Email, user.Role, u.jwtSecret, u.jwtExpiration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// Return response
 	return &response.LoginResponse{
-		Token: token,
-		User:  response.ToUserResponse(user),
+		Token:            token,
+		ExpiresAt:        time.Now().Add(u.jwtExpiration),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+		User:             response.ToUserResponse(user),
 	}, nil
 }
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestAuthUsecase(db *sql.DB) *authUsecase {
+	return NewAuthUsecase(
+		repository.NewUserRepository(db),
+		repository.NewSessionRepository(db),
+		repository.NewUserTokenRepository(db),
+		repository.NewLoginFailureRepository(db),
+		nil,
+		"test-secret",
+		15*time.Minute,
+		24*time.Hour,
+		24*time.Hour,
+		time.Hour,
+		"http://localhost:8080",
+		"http://localhost:3000",
+		LoginPolicy{MaxAttempts: 5, IPMaxAttempts: 20, BackoffBase: time.Second, LockoutDuration: 15 * time.Minute},
+	).(*authUsecase)
+}
+
+func TestVerifyEmailConsumesIssuedToken(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	uc := newTestAuthUsecase(db)
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+	if _, err := db.ExecContext(ctx, "UPDATE users SET email_verified_at = NULL WHERE id = $1", user.ID); err != nil {
+		t.Fatalf("failed to unverify user: %v", err)
+	}
+
+	token, err := uc.createUserToken(ctx, user.ID, domain.TokenPurposeEmailVerification, time.Hour)
+	if err != nil {
+		t.Fatalf("createUserToken: %v", err)
+	}
+
+	if err := uc.VerifyEmail(ctx, token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+
+	verified, err := uc.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !verified.IsEmailVerified() {
+		t.Fatal("email is not verified after VerifyEmail")
+	}
+
+	if err := uc.VerifyEmail(ctx, token); err == nil {
+		t.Fatal("VerifyEmail accepted a token that was already used")
+	}
+}
+
+func TestVerifyEmailRejectsInvalidToken(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	uc := newTestAuthUsecase(db)
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+
+	if err := uc.VerifyEmail(ctx, "not-a-token"); err == nil {
+		t.Fatal("VerifyEmail accepted an unknown token")
+	}
+
+	// A password reset token must not verify an email
+	token, err := uc.createUserToken(ctx, user.ID, domain.TokenPurposePasswordReset, time.Hour)
+	if err != nil {
+		t.Fatalf("createUserToken: %v", err)
+	}
+	if err := uc.VerifyEmail(ctx, token); err == nil {
+		t.Fatal("VerifyEmail accepted a password reset token")
+	}
+}
+
+func TestResetPasswordConsumesIssuedToken(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	uc := newTestAuthUsecase(db)
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+
+	token, err := uc.createUserToken(ctx, user.ID, domain.TokenPurposePasswordReset, time.Hour)
+	if err != nil {
+		t.Fatalf("createUserToken: %v", err)
+	}
+
+	if err := uc.ResetPassword(ctx, token, "new-password-123"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+
+	updated, err := uc.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(updated.PasswordHash), []byte("new-password-123")); err != nil {
+		t.Fatal("password was not changed by ResetPassword")
+	}
+
+	if err := uc.ResetPassword(ctx, token, "another-password-123"); err == nil {
+		t.Fatal("ResetPassword accepted a token that was already used")
+	}
+}
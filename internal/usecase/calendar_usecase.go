@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/utils"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// calendarFeedHistory is how long past events stay in calendar feeds
+const calendarFeedHistory = 180 * 24 * time.Hour
+
+// calendarFeedTokenSize is the entropy of calendar feed tokens in bytes
+const calendarFeedTokenSize = 32
+
+// calendarFeedName is the calendar name shown by apps subscribed to a feed
+const calendarFeedName = "Event Campus"
+
+// CalendarUsecase defines interface for iCalendar business logic
+type CalendarUsecase interface {
+	GetEventCalendar(ctx context.Context, eventID, userID uuid.UUID, role string) ([]byte, error)
+	GetFeed(ctx context.Context, token string) ([]byte, error)
+	CreateFeedToken(ctx context.Context, userID uuid.UUID) (*response.CalendarFeedResponse, error)
+	DeleteFeedToken(ctx context.Context, userID uuid.UUID) error
+}
+
+type calendarUsecase struct {
+	eventRepo repository.EventRepository
+	userRepo  repository.UserRepository
+	baseURL   string
+}
+
+// NewCalendarUsecase creates a new calendar usecase
+func NewCalendarUsecase(
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	baseURL string,
+) CalendarUsecase {
+	return &calendarUsecase{
+		eventRepo: eventRepo,
+		userRepo:  userRepo,
+		baseURL:   baseURL,
+	}
+}
+
+// GetEventCalendar builds the calendar file of an event. Like the event listings, drafts
+// are only visible to their organizer and to admins
+func (u *calendarUsecase) GetEventCalendar(ctx context.Context, eventID, userID uuid.UUID, role string) ([]byte, error) {
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	if event.Status == domain.StatusDraft && event.OrganizerID != userID && role != domain.RoleAdmin {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	return eventCalendar(event), nil
+}
+
+// GetFeed builds the calendar feed of the user owning token: the events they are registered
+// for or attended, up to calendarFeedHistory in the past
+func (u *calendarUsecase) GetFeed(ctx context.Context, token string) ([]byte, error) {
+	user, err := u.userRepo.GetByCalendarTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("calendar feed not found")
+	}
+
+	if user.IsSuspended() || user.IsDeleted() {
+		return nil, fmt.Errorf("calendar feed not found")
+	}
+
+	events, err := u.eventRepo.GetByParticipant(ctx, user.ID, time.Now().Add(-calendarFeedHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	calendarEvents := make([]utils.CalendarEvent, len(events))
+	for i := range events {
+		calendarEvents[i] = toCalendarEvent(&events[i])
+	}
+
+	return utils.GenerateICalendar(calendarFeedName, calendarEvents), nil
+}
+
+// CreateFeedToken creates the calendar feed of the user, replacing the previous feed URL
+func (u *calendarUsecase) CreateFeedToken(ctx context.Context, userID uuid.UUID) (*response.CalendarFeedResponse, error) {
+	token, err := utils.GenerateRandomToken(calendarFeedTokenSize)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHash := utils.HashToken(token)
+	if err := u.userRepo.SetCalendarTokenHash(ctx, userID, &tokenHash); err != nil {
+		return nil, err
+	}
+
+	return &response.CalendarFeedResponse{
+		URL: fmt.Sprintf("%s/api/v1/calendar/%s.ics", u.baseURL, token),
+	}, nil
+}
+
+// DeleteFeedToken disables the calendar feed of the user
+func (u *calendarUsecase) DeleteFeedToken(ctx context.Context, userID uuid.UUID) error {
+	return u.userRepo.SetCalendarTokenHash(ctx, userID, nil)
+}
+
+// eventCalendar renders a single event as an iCalendar file, as downloaded and emailed
+func eventCalendar(event *domain.Event) []byte {
+	return utils.GenerateICalendar("", []utils.CalendarEvent{toCalendarEvent(event)})
+}
+
+// toCalendarEvent maps an event to a VEVENT. The UID stays the same for the lifetime of the
+// event so every download, attachment and feed refers to the same calendar entry.
+func toCalendarEvent(event *domain.Event) utils.CalendarEvent {
+	calendarEvent := utils.CalendarEvent{
+		UID:          fmt.Sprintf("%s@event-campus", event.ID),
+		Sequence:     event.CalendarSequence,
+		Summary:      event.Title,
+		Description:  event.Description,
+		Latitude:     event.Latitude,
+		Longitude:    event.Longitude,
+		Start:        event.StartDate,
+		End:          event.EndDate,
+		Cancelled:    event.Status == domain.StatusCancelled,
+		LastModified: event.UpdatedAt,
+	}
+
+	if event.Location != nil {
+		calendarEvent.Location = *event.Location
+	}
+
+	if event.ZoomLink != nil && *event.ZoomLink != "" {
+		calendarEvent.URL = *event.ZoomLink
+		calendarEvent.Description += "\n\nLink Zoom: " + *event.ZoomLink
+		if calendarEvent.Location == "" {
+			calendarEvent.Location = *event.ZoomLink
+		}
+	}
+
+	if event.OrganizerName != nil {
+		calendarEvent.Description += "\n\nPenyelenggara: " + *event.OrganizerName
+	}
+
+	return calendarEvent
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGetEventCalendarHidesDrafts(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	eventRepo := repository.NewEventRepository(db)
+	uc := NewCalendarUsecase(eventRepo, repository.NewUserRepository(db), "http://localhost:8080")
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	student := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+	published := testutil.CreateEvent(t, db, organizer.ID, 10)
+	draft := testutil.CreateEvent(t, db, organizer.ID, 10)
+	if err := eventRepo.UpdateStatus(ctx, draft.ID, domain.StatusDraft); err != nil {
+		t.Fatalf("unpublish event: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		eventID uuid.UUID
+		userID  uuid.UUID
+		role    string
+		visible bool
+	}{
+		{"published to student", published.ID, student.ID, domain.RoleMahasiswa, true},
+		{"draft to student", draft.ID, student.ID, domain.RoleMahasiswa, false},
+		{"draft to other organizer", draft.ID, uuid.New(), domain.RoleOrganisasi, false},
+		{"draft to its organizer", draft.ID, organizer.ID, domain.RoleOrganisasi, true},
+		{"draft to admin", draft.ID, uuid.New(), domain.RoleAdmin, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.GetEventCalendar(ctx, tt.eventID, tt.userID, tt.role)
+			if tt.visible && err != nil {
+				t.Errorf("calendar not returned: %v", err)
+			}
+			if !tt.visible && err == nil {
+				t.Error("calendar returned for a hidden draft")
+			}
+		})
+	}
+}
@@ -0,0 +1,515 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/utils"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// certificateBatchSize caps how many certificates one IssueCertificates run issues and emails
+const certificateBatchSize = 200
+
+// maxCertificateFields caps the placements of a certificate template
+const maxCertificateFields = 20
+
+// certificateFieldNames are the values a certificate template can place
+var certificateFieldNames = []string{
+	domain.CertificateFieldParticipantName,
+	domain.CertificateFieldEventTitle,
+	domain.CertificateFieldEventDate,
+	domain.CertificateFieldOrganizerName,
+	domain.CertificateFieldSerialNumber,
+	domain.CertificateFieldVerificationHash,
+	domain.CertificateFieldVerificationURL,
+}
+
+// certificateFooterFontSize is the size of the verification line printed on certificates
+// whose template does not place the verification hash or URL itself
+const certificateFooterFontSize = 7
+
+// CertificateUsecase defines interface for certificate business logic
+type CertificateUsecase interface {
+	SaveTemplate(ctx context.Context, organizerID, eventID uuid.UUID, backgroundPath *string, fields []domain.CertificateField) (*domain.CertificateTemplate, *string, error)
+	GetTemplate(ctx context.Context, organizerID, eventID uuid.UUID) (*domain.CertificateTemplate, error)
+	GetCertificatePDF(ctx context.Context, userID, registrationID uuid.UUID) (*domain.Certificate, []byte, error)
+	IssueCertificates(ctx context.Context) (issued, emailed int, err error)
+	VerifyCertificate(ctx context.Context, serialNumber, hash string) (*response.CertificateVerificationResponse, error)
+	RevokeCertificate(ctx context.Context, organizerID, registrationID uuid.UUID, reason string) (*domain.Certificate, error)
+}
+
+type certificateUsecase struct {
+	certificateRepo  repository.CertificateRepository
+	eventRepo        repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+	userRepo         repository.UserRepository
+	emailSender      *utils.EmailSender
+	uploadPath       string
+	baseURL          string
+	signingKey       string
+}
+
+// NewCertificateUsecase creates a new certificate usecase
+func NewCertificateUsecase(
+	certificateRepo repository.CertificateRepository,
+	eventRepo repository.EventRepository,
+	registrationRepo repository.RegistrationRepository,
+	userRepo repository.UserRepository,
+	emailSender *utils.EmailSender,
+	uploadPath string,
+	baseURL string,
+	signingKey string,
+) CertificateUsecase {
+	return &certificateUsecase{
+		certificateRepo:  certificateRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		userRepo:         userRepo,
+		emailSender:      emailSender,
+		uploadPath:       uploadPath,
+		baseURL:          baseURL,
+		signingKey:       signingKey,
+	}
+}
+
+// SaveTemplate creates or replaces the certificate template of an event. backgroundPath may be
+// nil to keep the current background; the replaced background is returned for deletion.
+func (u *certificateUsecase) SaveTemplate(ctx context.Context, organizerID, eventID uuid.UUID, backgroundPath *string, fields []domain.CertificateField) (*domain.CertificateTemplate, *string, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, nil, fmt.Errorf("you don't have permission to manage certificates for this event")
+	}
+
+	if event.Status == domain.StatusCancelled {
+		return nil, nil, fmt.Errorf("event is cancelled")
+	}
+
+	if err := validateCertificateFields(fields); err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := u.certificateRepo.GetTemplateByEvent(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &domain.CertificateTemplate{
+		EventID: eventID,
+		Fields:  fields,
+	}
+
+	var oldBackgroundPath *string
+	switch {
+	case backgroundPath != nil:
+		template.BackgroundPath = *backgroundPath
+		if existing != nil {
+			oldBackgroundPath = &existing.BackgroundPath
+		}
+	case existing != nil:
+		template.BackgroundPath = existing.BackgroundPath
+	default:
+		return nil, nil, fmt.Errorf("background image is required")
+	}
+
+	if err := u.certificateRepo.SaveTemplate(ctx, template); err != nil {
+		return nil, nil, err
+	}
+
+	return template, oldBackgroundPath, nil
+}
+
+func (u *certificateUsecase) GetTemplate(ctx context.Context, organizerID, eventID uuid.UUID) (*domain.CertificateTemplate, error) {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to manage certificates for this event")
+	}
+
+	template, err := u.certificateRepo.GetTemplateByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return nil, fmt.Errorf("event has no certificate template")
+	}
+
+	return template, nil
+}
+
+// GetCertificatePDF renders the certificate of a registration for its participant or the
+// event organizer. A certificate the scheduler has not issued yet is issued on the spot.
+func (u *certificateUsecase) GetCertificatePDF(ctx context.Context, userID, registrationID uuid.UUID) (*domain.Certificate, []byte, error) {
+	registration, err := u.registrationRepo.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("registration not found")
+	}
+
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, registration.EventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if registration.UserID != userID && event.OrganizerID != userID {
+		return nil, nil, fmt.Errorf("you don't have permission to download this certificate")
+	}
+
+	template, err := u.certificateRepo.GetTemplateByEvent(ctx, event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if template == nil {
+		return nil, nil, fmt.Errorf("this event does not issue certificates")
+	}
+
+	// Checked on every download, attendance may have been removed after issuing
+	if !registration.IsAttended() {
+		return nil, nil, fmt.Errorf("certificates are only issued to participants who attended")
+	}
+
+	certificate, err := u.certificateRepo.GetByRegistration(ctx, registrationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if certificate != nil && certificate.IsRevoked() {
+		return nil, nil, fmt.Errorf("certificate has been revoked")
+	}
+
+	if certificate == nil {
+		if event.Status != domain.StatusCompleted {
+			return nil, nil, fmt.Errorf("certificates are issued once the event is completed")
+		}
+
+		user, err := u.userRepo.GetByID(ctx, registration.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("user not found")
+		}
+
+		if certificate, err = u.issue(ctx, registration, user.FullName); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pdf, err := u.render(ctx, certificate, event, template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certificate, pdf, nil
+}
+
+// IssueCertificates issues certificates to the attendees of completed events with a
+// template and emails the certificates not emailed yet, a batch at a time
+func (u *certificateUsecase) IssueCertificates(ctx context.Context) (issued, emailed int, err error) {
+	pending, err := u.certificateRepo.GetPendingRegistrations(ctx, certificateBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range pending {
+		registration := &pending[i]
+		if _, err := u.issue(ctx, registration, *registration.UserName); err != nil {
+			fmt.Printf("Failed to issue certificate for registration %s: %v\n", registration.ID, err)
+			continue
+		}
+		issued++
+	}
+
+	if u.emailSender == nil {
+		return issued, 0, nil
+	}
+
+	certificates, err := u.certificateRepo.GetNotEmailed(ctx, certificateBatchSize)
+	if err != nil {
+		return issued, 0, err
+	}
+
+	events := make(map[uuid.UUID]*domain.Event)
+	templates := make(map[uuid.UUID]*domain.CertificateTemplate)
+
+	for i := range certificates {
+		certificate := &certificates[i]
+
+		event, ok := events[certificate.EventID]
+		if !ok {
+			if event, err = u.eventRepo.GetByID(ctx, certificate.EventID); err != nil {
+				fmt.Printf("Failed to get event %s for certificate: %v\n", certificate.EventID, err)
+				continue
+			}
+			events[certificate.EventID] = event
+		}
+
+		template, ok := templates[certificate.EventID]
+		if !ok {
+			if template, err = u.certificateRepo.GetTemplateByEvent(ctx, certificate.EventID); err != nil || template == nil {
+				fmt.Printf("Failed to get certificate template of event %s: %v\n", certificate.EventID, err)
+				continue
+			}
+			templates[certificate.EventID] = template
+		}
+
+		pdf, err := u.render(ctx, certificate, event, template)
+		if err != nil {
+			fmt.Printf("Failed to render certificate %s: %v\n", certificate.SerialNumber, err)
+			continue
+		}
+
+		if err := u.emailSender.SendCertificate(*certificate.UserEmail, certificate.ParticipantName, event.Title, certificate.SerialNumber, pdf); err != nil {
+			fmt.Printf("Failed to send certificate %s: %v\n", certificate.SerialNumber, err)
+			continue
+		}
+
+		if err := u.certificateRepo.MarkEmailed(ctx, certificate.ID); err != nil {
+			fmt.Printf("Failed to mark certificate %s emailed: %v\n", certificate.SerialNumber, err)
+			continue
+		}
+		emailed++
+	}
+
+	return issued, emailed, nil
+}
+
+// VerifyCertificate looks up a certificate by its serial number for anyone checking its
+// authenticity. hash is the verification hash printed on the certificate and may be empty.
+func (u *certificateUsecase) VerifyCertificate(ctx context.Context, serialNumber, hash string) (*response.CertificateVerificationResponse, error) {
+	serialNumber = strings.ToUpper(strings.TrimSpace(serialNumber))
+
+	certificate, err := u.certificateRepo.GetBySerial(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate == nil {
+		return nil, fmt.Errorf("certificate not found")
+	}
+
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, certificate.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	organizerName := ""
+	if organizer, err := u.userRepo.GetByID(ctx, event.OrganizerID); err == nil {
+		organizerName = organizer.FullName
+	}
+
+	resp := &response.CertificateVerificationResponse{
+		SerialNumber:     certificate.SerialNumber,
+		Valid:            !certificate.IsRevoked(),
+		ParticipantName:  certificate.ParticipantName,
+		EventTitle:       event.Title,
+		EventDate:        event.StartDate,
+		OrganizerName:    organizerName,
+		IssuedAt:         certificate.IssuedAt,
+		Revoked:          certificate.IsRevoked(),
+		RevokedAt:        certificate.RevokedAt,
+		RevocationReason: certificate.RevocationReason,
+	}
+
+	if hash = strings.TrimSpace(hash); hash != "" {
+		matches := utils.ValidateCertificateHash(hash, certificate.SerialNumber, certificate.ParticipantName, certificate.RegistrationID, certificate.EventID, u.signingKey)
+		resp.HashMatches = &matches
+		resp.Valid = resp.Valid && matches
+	}
+
+	return resp, nil
+}
+
+// RevokeCertificate revokes the certificate of a registration, e.g. one issued for attendance
+// that was marked by mistake. Verification reports it as revoked from then on.
+func (u *certificateUsecase) RevokeCertificate(ctx context.Context, organizerID, registrationID uuid.UUID, reason string) (*domain.Certificate, error) {
+	registration, err := u.registrationRepo.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, registration.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return nil, fmt.Errorf("you don't have permission to manage certificates for this event")
+	}
+
+	certificate, err := u.certificateRepo.GetByRegistration(ctx, registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate == nil {
+		return nil, fmt.Errorf("no certificate has been issued for this registration")
+	}
+
+	revoked, err := u.certificateRepo.Revoke(ctx, certificate.ID, organizerID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if !revoked {
+		return nil, fmt.Errorf("certificate is already revoked")
+	}
+
+	return u.certificateRepo.GetByRegistration(ctx, registrationID)
+}
+
+// issue creates the certificate of a registration, or returns the one issued concurrently
+func (u *certificateUsecase) issue(ctx context.Context, registration *domain.Registration, participantName string) (*domain.Certificate, error) {
+	serial, err := utils.GenerateCertificateSerial(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &domain.Certificate{
+		RegistrationID:  registration.ID,
+		SerialNumber:    serial,
+		ParticipantName: participantName,
+		EventID:         registration.EventID,
+		UserID:          registration.UserID,
+	}
+
+	created, err := u.certificateRepo.Create(ctx, certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return u.certificateRepo.GetByRegistration(ctx, registration.ID)
+	}
+
+	return certificate, nil
+}
+
+// render lays out a certificate on its event's template as PDF
+func (u *certificateUsecase) render(ctx context.Context, certificate *domain.Certificate, event *domain.Event, template *domain.CertificateTemplate) ([]byte, error) {
+	organizerName := ""
+	if organizer, err := u.userRepo.GetByID(ctx, event.OrganizerID); err == nil {
+		organizerName = organizer.FullName
+	}
+
+	values := map[string]string{
+		domain.CertificateFieldParticipantName:  certificate.ParticipantName,
+		domain.CertificateFieldEventTitle:       event.Title,
+		domain.CertificateFieldEventDate:        event.StartDate.Format("02 January 2006"),
+		domain.CertificateFieldOrganizerName:    organizerName,
+		domain.CertificateFieldSerialNumber:     certificate.SerialNumber,
+		domain.CertificateFieldVerificationHash: u.hash(certificate),
+		domain.CertificateFieldVerificationURL:  u.verificationURL(certificate),
+	}
+
+	placesVerification := false
+
+	texts := make([]utils.CertificateText, 0, len(template.Fields)+1)
+	for _, field := range template.Fields {
+		if field.Name == domain.CertificateFieldVerificationHash || field.Name == domain.CertificateFieldVerificationURL {
+			placesVerification = true
+		}
+
+		texts = append(texts, utils.CertificateText{
+			Text:     values[field.Name],
+			X:        field.X,
+			Y:        field.Y,
+			FontSize: field.FontSize,
+			Bold:     field.Bold,
+			Color:    field.Color,
+			Align:    field.Align,
+		})
+	}
+
+	// Every certificate carries its verification details, along the bottom edge when the
+	// template does not place them
+	if !placesVerification {
+		texts = append(texts, utils.CertificateText{
+			Text:     fmt.Sprintf("Verifikasi: %s  |  Hash: %s", values[domain.CertificateFieldVerificationURL], values[domain.CertificateFieldVerificationHash]),
+			X:        domain.CertificatePageWidth / 2,
+			Y:        domain.CertificatePageHeight - 5,
+			FontSize: certificateFooterFontSize,
+		})
+	}
+
+	return utils.GenerateCertificatePDF(
+		filepath.Join(u.uploadPath, template.BackgroundPath),
+		domain.CertificatePageWidth,
+		domain.CertificatePageHeight,
+		texts,
+	)
+}
+
+// hash computes the verification hash printed on a certificate
+func (u *certificateUsecase) hash(certificate *domain.Certificate) string {
+	return utils.GenerateCertificateHash(certificate.SerialNumber, certificate.ParticipantName, certificate.RegistrationID, certificate.EventID, u.signingKey)
+}
+
+// verificationURL links to the public verification of a certificate
+func (u *certificateUsecase) verificationURL(certificate *domain.Certificate) string {
+	return fmt.Sprintf("%s/api/v1/certificates/verify/%s", u.baseURL, certificate.SerialNumber)
+}
+
+// validateCertificateFields checks that every placement is on the page and that the
+// participant name and serial number are printed
+func validateCertificateFields(fields []domain.CertificateField) error {
+	if len(fields) == 0 || len(fields) > maxCertificateFields {
+		return fmt.Errorf("a certificate template needs between 1 and %d fields", maxCertificateFields)
+	}
+
+	for _, field := range fields {
+		if !slices.Contains(certificateFieldNames, field.Name) {
+			return fmt.Errorf("unknown certificate field %q", field.Name)
+		}
+
+		if field.X < 0 || field.X > domain.CertificatePageWidth || field.Y < 0 || field.Y > domain.CertificatePageHeight {
+			return fmt.Errorf("field %s must be within the %dx%d mm page", field.Name, domain.CertificatePageWidth, domain.CertificatePageHeight)
+		}
+
+		if field.FontSize < 4 || field.FontSize > 120 {
+			return fmt.Errorf("font_size of field %s must be between 4 and 120", field.Name)
+		}
+
+		if field.Color != "" {
+			if _, _, _, err := utils.ParseHexColor(field.Color); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		switch field.Align {
+		case "", domain.CertificateAlignLeft, domain.CertificateAlignCenter, domain.CertificateAlignRight:
+		default:
+			return fmt.Errorf("align of field %s must be left, center or right", field.Name)
+		}
+	}
+
+	hasField := func(name string) bool {
+		return slices.ContainsFunc(fields, func(f domain.CertificateField) bool { return f.Name == name })
+	}
+	if !hasField(domain.CertificateFieldParticipantName) || !hasField(domain.CertificateFieldSerialNumber) {
+		return fmt.Errorf("a certificate template must place participant_name and serial_number")
+	}
+
+	return nil
+}
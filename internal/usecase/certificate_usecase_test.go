@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRemoveAttendanceRevokesCertificate(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+
+	registrations := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+	attendance := NewAttendanceUsecase(
+		repository.NewAttendanceRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewRegistrationRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTxManager(db),
+		"test-secret",
+	)
+	certificates := NewCertificateUsecase(
+		repository.NewCertificateRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewRegistrationRepository(db),
+		repository.NewUserRepository(db),
+		nil,
+		t.TempDir(),
+		"http://localhost:8080",
+		"test-secret",
+	).(*certificateUsecase)
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 10)
+	registration := registerUser(t, db, registrations, event.ID)
+
+	// The event took place and the participant attended
+	_, err := db.ExecContext(ctx, `
+		UPDATE events SET start_date = $1, end_date = $2, status = $3 WHERE id = $4
+	`, time.Now().Add(-3*time.Hour), time.Now().Add(-time.Hour), domain.StatusCompleted, event.ID)
+	if err != nil {
+		t.Fatalf("failed to complete event: %v", err)
+	}
+	if err := attendance.MarkAttendance(ctx, organizer.ID, event.ID, registration.UserID, nil); err != nil {
+		t.Fatalf("MarkAttendance: %v", err)
+	}
+
+	template := &domain.CertificateTemplate{EventID: event.ID, BackgroundPath: "certificates/background.png"}
+	if err := certificates.certificateRepo.SaveTemplate(ctx, template); err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+
+	certificate, err := certificates.issue(ctx, getRegistration(t, registrations, registration.ID), "Test User")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	verification, err := certificates.VerifyCertificate(ctx, certificate.SerialNumber, certificates.hash(certificate))
+	if err != nil {
+		t.Fatalf("VerifyCertificate: %v", err)
+	}
+	if !verification.Valid || verification.HashMatches == nil || !*verification.HashMatches {
+		t.Fatal("certificate with its printed hash does not verify")
+	}
+
+	verification, err = certificates.VerifyCertificate(ctx, certificate.SerialNumber, "0000-0000-0000-0000-0000-0000-0000-0000")
+	if err != nil {
+		t.Fatalf("VerifyCertificate: %v", err)
+	}
+	if verification.Valid || verification.HashMatches == nil || *verification.HashMatches {
+		t.Fatal("certificate verifies with a forged hash")
+	}
+
+	if err := attendance.RemoveAttendance(ctx, organizer.ID, event.ID, registration.UserID); err != nil {
+		t.Fatalf("RemoveAttendance: %v", err)
+	}
+
+	if _, _, err := certificates.GetCertificatePDF(ctx, registration.UserID, registration.ID); err == nil || !strings.Contains(err.Error(), "attended") {
+		t.Fatalf("GetCertificatePDF error = %v, want attendance required", err)
+	}
+
+	verification, err = certificates.VerifyCertificate(ctx, certificate.SerialNumber, "")
+	if err != nil {
+		t.Fatalf("VerifyCertificate: %v", err)
+	}
+	if verification.Valid || !verification.Revoked {
+		t.Fatalf("verification valid = %v, revoked = %v, want a revoked invalid certificate", verification.Valid, verification.Revoked)
+	}
+}
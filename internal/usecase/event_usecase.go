@@ -8,6 +8,7 @@ import (
 	"event-campus-backend/internal/repository"
 	"event-campus-backend/internal/utils"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,10 +18,12 @@ import (
 type EventUsecase interface {
 	CreateEvent(ctx context.Context, organizerID uuid.UUID, req *request.CreateEventRequest, posterPath *string) (*domain.Event, error)
 	GetEvent(ctx context.Context, id uuid.UUID) (*response.EventResponse, error)
-	GetAllEvents(ctx context.Context, filters map[string]interface{}) ([]response.EventResponse, error)
-	GetMyEvents(ctx context.Context, organizerID uuid.UUID) ([]response.EventResponse, error)
+	GetAllEvents(ctx context.Context, filter repository.EventFilter, opts repository.ListOptions) ([]response.EventResponse, *response.PaginationMeta, error)
+	SearchEvents(ctx context.Context, query string, filter repository.EventFilter, opts repository.ListOptions) ([]response.EventSearchResponse, *response.PaginationMeta, error)
+	GetMyEvents(ctx context.Context, organizerID uuid.UUID, opts repository.ListOptions) ([]response.EventResponse, *response.PaginationMeta, error)
 	UpdateEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID, req *request.UpdateEventRequest, posterPath *string) error
 	DeleteEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID) error
+	CancelEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID, reason string) error
 	PublishEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID) error
 	SendReminders(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID) error
 }
@@ -29,8 +32,10 @@ type eventUsecase struct {
 	eventRepo        repository.EventRepository
 	userRepo         repository.UserRepository
 	registrationRepo repository.RegistrationRepository
+	txManager        repository.TxManager
 	emailSender      *utils.EmailSender
 	baseURL          string
+	checkInSecret    string
 }
 
 // NewEventUsecase creates a new event usecase
@@ -38,15 +43,19 @@ func NewEventUsecase(
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
 	registrationRepo repository.RegistrationRepository,
+	txManager repository.TxManager,
 	emailSender *utils.EmailSender,
 	baseURL string,
+	checkInSecret string,
 ) EventUsecase {
 	return &eventUsecase{
 		eventRepo:        eventRepo,
 		userRepo:         userRepo,
 		registrationRepo: registrationRepo,
+		txManager:        txManager,
 		emailSender:      emailSender,
 		baseURL:          baseURL,
+		checkInSecret:    checkInSecret,
 	}
 }
 
@@ -86,6 +95,9 @@ func (u *eventUsecase) CreateEvent(ctx context.Context, organizerID uuid.UUID, r
 		Category:             req.Category,
 		EventType:            req.EventType,
 		Location:             req.Location,
+		Latitude:             req.Latitude,
+		Longitude:            req.Longitude,
+		CheckInRadiusMeters:  req.CheckInRadiusMeters,
 		ZoomLink:             req.ZoomLink,
 		PosterPath:           posterPath,
 		StartDate:            req.StartDate,
@@ -96,6 +108,10 @@ func (u *eventUsecase) CreateEvent(ctx context.Context, organizerID uuid.UUID, r
 		Status:               domain.StatusDraft,
 	}
 
+	if err := validateGeofence(event); err != nil {
+		return nil, err
+	}
+
 	if err := u.eventRepo.Create(ctx, event); err != nil {
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
@@ -109,49 +125,60 @@ func (u *eventUsecase) GetEvent(ctx context.Context, id uuid.UUID) (*response.Ev
 		return nil, fmt.Errorf("event not found")
 	}
 
-	// Get organizer info
-	organizer, err := u.userRepo.GetByID(ctx, event.OrganizerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get organizer: %w", err)
-	}
-
 	resp := response.ToEventResponse(event, u.baseURL)
-	resp.OrganizerName = organizer.FullName
 
 	return &resp, nil
 }
 
-func (u *eventUsecase) GetAllEvents(ctx context.Context, filters map[string]interface{}) ([]response.EventResponse, error) {
+func (u *eventUsecase) GetAllEvents(ctx context.Context, filter repository.EventFilter, opts repository.ListOptions) ([]response.EventResponse, *response.PaginationMeta, error) {
 	// Only show published events unless admin/organizer
-	if _, ok := filters["status"]; !ok {
-		filters["status"] = domain.StatusPublished
+	if filter.Status == "" {
+		filter.Status = domain.StatusPublished
 	}
 
-	events, err := u.eventRepo.GetAll(ctx, filters)
+	events, page, err := u.eventRepo.GetAll(ctx, filter, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get events: %w", err)
+		return nil, nil, fmt.Errorf("failed to get events: %w", err)
 	}
 
+	// Organizer names are joined in by the repository
 	var responses []response.EventResponse
 	for _, event := range events {
-		resp := response.ToEventResponse(&event, u.baseURL)
+		responses = append(responses, response.ToEventResponse(&event, u.baseURL))
+	}
 
-		// Get organizer name
-		organizer, err := u.userRepo.GetByID(ctx, event.OrganizerID)
-		if err == nil {
-			resp.OrganizerName = organizer.FullName
-		}
+	return responses, response.NewPaginationMeta(opts.Page, opts.Limit, page.TotalItems, page.NextCursor), nil
+}
 
-		responses = append(responses, resp)
+func (u *eventUsecase) SearchEvents(ctx context.Context, query string, filter repository.EventFilter, opts repository.ListOptions) ([]response.EventSearchResponse, *response.PaginationMeta, error) {
+	// Only search published events unless a status is requested
+	if filter.Status == "" {
+		filter.Status = domain.StatusPublished
 	}
 
-	return responses, nil
+	results, page, err := u.eventRepo.Search(ctx, strings.TrimSpace(query), filter, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search events: %w", err)
+	}
+
+	var responses []response.EventSearchResponse
+	for _, result := range results {
+		responses = append(responses, response.EventSearchResponse{
+			EventResponse:  response.ToEventResponse(&result.Event, u.baseURL),
+			Rank:           result.Rank,
+			TitleHighlight: result.TitleHighlight,
+			Snippet:        result.Snippet,
+			Fuzzy:          result.Fuzzy,
+		})
+	}
+
+	return responses, response.NewPaginationMeta(opts.Page, opts.Limit, page.TotalItems, ""), nil
 }
 
-func (u *eventUsecase) GetMyEvents(ctx context.Context, organizerID uuid.UUID) ([]response.EventResponse, error) {
-	events, err := u.eventRepo.GetByOrganizer(ctx, organizerID)
+func (u *eventUsecase) GetMyEvents(ctx context.Context, organizerID uuid.UUID, opts repository.ListOptions) ([]response.EventResponse, *response.PaginationMeta, error) {
+	events, page, err := u.eventRepo.GetByOrganizer(ctx, organizerID, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get events: %w", err)
+		return nil, nil, fmt.Errorf("failed to get events: %w", err)
 	}
 
 	var responses []response.EventResponse
@@ -159,7 +186,7 @@ func (u *eventUsecase) GetMyEvents(ctx context.Context, organizerID uuid.UUID) (
 		responses = append(responses, response.ToEventResponse(&event, u.baseURL))
 	}
 
-	return responses, nil
+	return responses, response.NewPaginationMeta(opts.Page, opts.Limit, page.TotalItems, page.NextCursor), nil
 }
 
 func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID, req *request.UpdateEventRequest, posterPath *string) error {
@@ -184,6 +211,7 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 	oldEndDate := event.EndDate
 	oldLocation := event.Location
 	oldZoomLink := event.ZoomLink
+	oldMaxParticipants := event.MaxParticipants
 
 	// Validate dates if provided
 	if req.StartDate != nil {
@@ -223,6 +251,20 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 	if req.Location != nil {
 		event.Location = req.Location
 	}
+	if req.Latitude != nil {
+		event.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		event.Longitude = req.Longitude
+	}
+	if req.CheckInRadiusMeters != nil {
+		event.CheckInRadiusMeters = req.CheckInRadiusMeters
+		if *req.CheckInRadiusMeters == 0 {
+			event.Latitude = nil
+			event.Longitude = nil
+			event.CheckInRadiusMeters = nil
+		}
+	}
 	if req.ZoomLink != nil {
 		event.ZoomLink = req.ZoomLink
 	}
@@ -240,6 +282,10 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 		event.IsUIIOnly = *req.IsUIIOnly
 	}
 
+	if err := validateGeofence(event); err != nil {
+		return err
+	}
+
 	// Detect changes
 	var changes []string
 	if !event.StartDate.Equal(oldStartDate) {
@@ -275,11 +321,27 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 		changes = append(changes, "Link Zoom telah diperbarui")
 	}
 
-	// Update event
-	if err := u.eventRepo.Update(ctx, event); err != nil {
-		return fmt.Errorf("failed to update event: %w", err)
+	// Update event, moving waitlisted users into any seats added by a capacity increase
+	var promoted []domain.Registration
+	err = u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if err := repos.Events.Update(ctx, event); err != nil {
+			return fmt.Errorf("failed to update event: %w", err)
+		}
+
+		if event.MaxParticipants <= oldMaxParticipants {
+			return nil
+		}
+
+		var err error
+		promoted, err = repos.Registrations.FillFromWaitlist(ctx, eventID)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
+	u.notifyWaitlistPromotions(ctx, event, promoted)
+
 	// Send notifications if there are critical changes and event is published
 	if len(changes) > 0 && event.Status == domain.StatusPublished {
 		go func() {
@@ -293,6 +355,9 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 				return
 			}
 
+			// Same UID with a higher SEQUENCE, so calendars replace the entry from earlier emails
+			calendar := eventCalendar(event)
+
 			for _, reg := range registrations {
 				user, err := u.userRepo.GetByID(bgCtx, reg.UserID)
 				if err != nil {
@@ -306,6 +371,7 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 						event.Title,
 						event.StartDate,
 						changes,
+						calendar,
 					); err != nil {
 						fmt.Printf("Failed to send update email to %s: %v\n", user.Email, err)
 					}
@@ -317,6 +383,42 @@ func (u *eventUsecase) UpdateEvent(ctx context.Context, organizerID uuid.UUID, e
 	return nil
 }
 
+// validateGeofence checks that venue coordinates come complete and only for offline events
+func validateGeofence(event *domain.Event) error {
+	if event.Latitude == nil && event.Longitude == nil && event.CheckInRadiusMeters == nil {
+		return nil
+	}
+
+	if !event.HasGeofence() {
+		return fmt.Errorf("latitude, longitude and check_in_radius_meters must be set together")
+	}
+
+	if !event.IsOffline() {
+		return fmt.Errorf("check-in geofence is only available for offline events")
+	}
+
+	return nil
+}
+
+// notifyWaitlistPromotions emails every user promoted from the waitlist of the event
+func (u *eventUsecase) notifyWaitlistPromotions(ctx context.Context, event *domain.Event, promoted []domain.Registration) {
+	if u.emailSender == nil {
+		return
+	}
+
+	for _, reg := range promoted {
+		user, err := u.userRepo.GetByID(ctx, reg.UserID)
+		if err != nil {
+			fmt.Printf("Failed to get user %s for promotion email: %v\n", reg.UserID, err)
+			continue
+		}
+
+		if err := u.emailSender.SendWaitlistPromotion(user.Email, user.FullName, event.Title, event.StartDate, reg.ID.String()); err != nil {
+			fmt.Printf("Failed to send promotion email to %s: %v\n", user.Email, err)
+		}
+	}
+}
+
 func (u *eventUsecase) DeleteEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID) error {
 	// Get event
 	event, err := u.eventRepo.GetByID(ctx, eventID)
@@ -329,13 +431,9 @@ func (u *eventUsecase) DeleteEvent(ctx context.Context, organizerID uuid.UUID, e
 		return fmt.Errorf("you don't have permission to delete this event")
 	}
 
-	// Can only delete draft events or cancel published ones
+	// Only drafts can be deleted, published events have to be cancelled with a reason
 	if event.Status != domain.StatusDraft {
-		// Change status to cancelled instead of deleting
-		if err := u.eventRepo.UpdateStatus(ctx, eventID, domain.StatusCancelled); err != nil {
-			return fmt.Errorf("failed to cancel event: %w", err)
-		}
-		return nil
+		return fmt.Errorf("only draft events can be deleted, cancel the event instead")
 	}
 
 	// Delete draft event
@@ -346,6 +444,69 @@ func (u *eventUsecase) DeleteEvent(ctx context.Context, organizerID uuid.UUID, e
 	return nil
 }
 
+func (u *eventUsecase) CancelEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("cancellation reason is required")
+	}
+
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return fmt.Errorf("you don't have permission to cancel this event")
+	}
+
+	switch event.Status {
+	case domain.StatusDraft:
+		return fmt.Errorf("draft events cannot be cancelled, delete them instead")
+	case domain.StatusCompleted:
+		return fmt.Errorf("completed events cannot be cancelled")
+	case domain.StatusCancelled:
+		return fmt.Errorf("event is already cancelled")
+	}
+
+	// Cancel the event together with all of its registrations
+	var cancelled []domain.Registration
+	err = u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if err := repos.Events.Cancel(ctx, eventID, reason); err != nil {
+			return err
+		}
+
+		var err error
+		cancelled, err = repos.Registrations.CancelByEvent(ctx, eventID, reason)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel event: %w", err)
+	}
+
+	// Notify registered and waitlisted users
+	if u.emailSender != nil && len(cancelled) > 0 {
+		go func() {
+			// Create a background context for email sending
+			bgCtx := context.Background()
+
+			for _, reg := range cancelled {
+				user, err := u.userRepo.GetByID(bgCtx, reg.UserID)
+				if err != nil {
+					continue
+				}
+
+				if err := u.emailSender.SendEventCancellation(user.Email, user.FullName, event.Title, event.StartDate, reason); err != nil {
+					fmt.Printf("Failed to send event cancellation email to %s: %v\n", user.Email, err)
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
 func (u *eventUsecase) PublishEvent(ctx context.Context, organizerID uuid.UUID, eventID uuid.UUID) error {
 	// Get event
 	event, err := u.eventRepo.GetByID(ctx, eventID)
@@ -429,6 +590,7 @@ func (u *eventUsecase) SendReminders(ctx context.Context, organizerID uuid.UUID,
 					location,
 					event.ZoomLink,
 					reg.ID.String(),
+					utils.GenerateCheckInToken(reg.ID, event.ID, u.checkInSecret),
 				)
 				if err != nil {
 					fmt.Printf("Failed to send manual reminder to %s: %v\n", user.Email, err)
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"fmt"
+	"testing"
+)
+
+// newCountingEventUsecase creates an event usecase whose repositories run on a CountingDB
+func newCountingEventUsecase(db *sql.DB) (*eventUsecase, *testutil.CountingDB) {
+	counting := testutil.NewCountingDB(db)
+	uc := NewEventUsecase(
+		repository.NewEventRepository(counting),
+		repository.NewUserRepository(counting),
+		repository.NewRegistrationRepository(counting),
+		repository.NewTxManager(db),
+		nil,
+		"http://localhost:8080",
+		"test-secret",
+	).(*eventUsecase)
+
+	return uc, counting
+}
+
+func TestEventListingQueryCountIsConstant(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+	uc, counting := newCountingEventUsecase(db)
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	for i := 0; i < 30; i++ {
+		testutil.CreateEvent(t, db, organizer.ID, 10)
+	}
+
+	listings := map[string]func(opts repository.ListOptions) (int, error){
+		"GetAllEvents": func(opts repository.ListOptions) (int, error) {
+			events, _, err := uc.GetAllEvents(ctx, repository.EventFilter{OrganizerID: &organizer.ID}, opts)
+			return len(events), err
+		},
+		"GetMyEvents": func(opts repository.ListOptions) (int, error) {
+			events, _, err := uc.GetMyEvents(ctx, organizer.ID, opts)
+			return len(events), err
+		},
+	}
+
+	for name, list := range listings {
+		t.Run(name, func(t *testing.T) {
+			want := -1
+			for _, limit := range []int{1, 10, 30} {
+				counting.Reset()
+				n, err := list(repository.ListOptions{Page: 1, Limit: limit})
+				if err != nil {
+					t.Fatalf("limit %d: %v", limit, err)
+				}
+				if n != limit {
+					t.Fatalf("limit %d: got %d events", limit, n)
+				}
+
+				if want == -1 {
+					want = counting.Queries()
+				}
+				if got := counting.Queries(); got != want {
+					t.Errorf("limit %d: %d queries, want %d as for limit 1", limit, got, want)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetAllEvents(b *testing.B) {
+	db := testutil.DB(b)
+	ctx := context.Background()
+	uc, counting := newCountingEventUsecase(db)
+
+	organizer := testutil.CreateUser(b, db, domain.RoleOrganisasi)
+	for i := 0; i < 100; i++ {
+		testutil.CreateEvent(b, db, organizer.ID, 10)
+	}
+	filter := repository.EventFilter{OrganizerID: &organizer.ID}
+
+	for _, limit := range []int{10, 50, 100} {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			counting.Reset()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := uc.GetAllEvents(ctx, filter, repository.ListOptions{Page: 1, Limit: limit}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(counting.Queries())/float64(b.N), "queries/op")
+		})
+	}
+}
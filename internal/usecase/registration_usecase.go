@@ -2,27 +2,48 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/dto/response"
 	"event-campus-backend/internal/repository"
 	"event-campus-backend/internal/utils"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// WaitlistPolicy configures how freed seats reach waitlisted users
+type WaitlistPolicy struct {
+	OfferMode bool          // offer the seat with a claim deadline instead of promoting directly
+	OfferTTL  time.Duration // time a waitlisted user has to claim an offered seat
+}
+
+// errCannotCancel is returned when a registration is no longer registered, waitlisted or offered
+var errCannotCancel = errors.New("cannot cancel this registration")
+
 // RegistrationUsecase defines interface for registration business logic
 type RegistrationUsecase interface {
 	RegisterForEvent(ctx context.Context, userID, eventID uuid.UUID) (*domain.Registration, error)
 	CancelRegistration(ctx context.Context, userID, registrationID uuid.UUID) error
-	GetMyRegistrations(ctx context.Context, userID uuid.UUID) ([]domain.Registration, error)
+	ClaimOffer(ctx context.Context, userID, registrationID uuid.UUID) (*domain.Registration, error)
+	GetCheckInQRCode(ctx context.Context, userID, registrationID uuid.UUID) ([]byte, error)
+	ExpireWaitlistOffers(ctx context.Context) (int, error)
+	GetMyRegistrations(ctx context.Context, userID uuid.UUID, opts repository.ListOptions) ([]domain.Registration, *response.PaginationMeta, error)
 	GetEventRegistrations(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.Registration, error)
+	ExportEventRegistrations(ctx context.Context, organizerID, eventID uuid.UUID, fn func(*domain.Registration) error) error
+	CancelUpcomingRegistrations(ctx context.Context, userID uuid.UUID) (int, error)
 }
 
 type registrationUsecase struct {
-	registrationRepo repository.RegistrationRepository
-	eventRepo        repository.EventRepository
-	userRepo         repository.UserRepository
-	emailSender      *utils.EmailSender
+	registrationRepo         repository.RegistrationRepository
+	eventRepo                repository.EventRepository
+	userRepo                 repository.UserRepository
+	txManager                repository.TxManager
+	emailSender              *utils.EmailSender
+	requireEmailVerification bool
+	waitlistPolicy           WaitlistPolicy
+	checkInSecret            string
 }
 
 // NewRegistrationUsecase creates a new registration usecase
@@ -30,13 +51,21 @@ func NewRegistrationUsecase(
 	registrationRepo repository.RegistrationRepository,
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
+	txManager repository.TxManager,
 	emailSender *utils.EmailSender,
+	requireEmailVerification bool,
+	waitlistPolicy WaitlistPolicy,
+	checkInSecret string,
 ) RegistrationUsecase {
 	return &registrationUsecase{
-		registrationRepo: registrationRepo,
-		eventRepo:        eventRepo,
-		userRepo:         userRepo,
-		emailSender:      emailSender,
+		registrationRepo:         registrationRepo,
+		eventRepo:                eventRepo,
+		userRepo:                 userRepo,
+		txManager:                txManager,
+		emailSender:              emailSender,
+		requireEmailVerification: requireEmailVerification,
+		waitlistPolicy:           waitlistPolicy,
+		checkInSecret:            checkInSecret,
 	}
 }
 
@@ -58,6 +87,12 @@ func (u *registrationUsecase) RegisterForEvent(ctx context.Context, userID, even
 		return nil, fmt.Errorf("user not found")
 	}
 
+	// UII civitas status is derived from the email address, so it only
+	// counts once the user has proven they own that address
+	if u.requireEmailVerification && !user.IsEmailVerified() {
+		return nil, fmt.Errorf("please verify your email address before registering for events")
+	}
+
 	// Check if event is UII only
 	if event.IsUIIOnly && !user.IsUIICivitas {
 		return nil, fmt.Errorf("this event is only for UII civitas")
@@ -76,39 +111,28 @@ func (u *registrationUsecase) RegisterForEvent(ctx context.Context, userID, even
 		if existingReg.IsWaitlist() {
 			return nil, fmt.Errorf("you are already in the waitlist for this event")
 		}
+		if existingReg.IsOffered() {
+			return nil, fmt.Errorf("a seat is already offered to you for this event, claim it from your registrations")
+		}
 	}
 
-	// Determine registration status based on capacity
-	status := domain.RegistrationStatusRegistered
-	if event.IsFull() {
-		status = domain.RegistrationStatusWaitlist
-	}
-
-	// Create registration
+	// Registered while seats remain, waitlisted otherwise. The capacity check and
+	// participant count update happen atomically with the insert.
 	registration := &domain.Registration{
 		EventID:      eventID,
 		UserID:       userID,
-		Status:       status,
 		ReminderSent: false,
 	}
 
-	if err := u.registrationRepo.Create(ctx, registration); err != nil {
+	if err := u.registrationRepo.CreateWithSeat(ctx, registration); err != nil {
 		return nil, fmt.Errorf("failed to create registration: %w", err)
 	}
 
-	// Increment participant count if registered (not waitlisted)
-	if status == domain.RegistrationStatusRegistered {
-		if err := u.eventRepo.IncrementParticipants(ctx, eventID); err != nil {
-			return nil, fmt.Errorf("failed to update participant count: %w", err)
-		}
-
+	if registration.IsRegistered() {
 		// Send confirmation email
 		if u.emailSender != nil {
-			zoomLink := ""
-			if event.ZoomLink != nil {
-				zoomLink = *event.ZoomLink
-			}
-			if err := u.emailSender.SendRegistrationConfirmation(user.Email, user.FullName, event.Title, event.StartDate, zoomLink); err != nil {
+			checkInToken := utils.GenerateCheckInToken(registration.ID, eventID, u.checkInSecret)
+			if err := u.emailSender.SendRegistrationConfirmation(user.Email, user.FullName, event.Title, event.StartDate, registration.ID.String(), checkInToken, eventCalendar(event)); err != nil {
 				// Log error but don't fail
 				fmt.Printf("Failed to send confirmation email: %v\n", err)
 			}
@@ -116,8 +140,8 @@ func (u *registrationUsecase) RegisterForEvent(ctx context.Context, userID, even
 	} else {
 		// Send waitlist notification
 		if u.emailSender != nil {
-			waitlistCount, _ := u.registrationRepo.CountByEventAndStatus(ctx, eventID, domain.RegistrationStatusWaitlist)
-			if err := u.emailSender.SendWaitlistNotification(user.Email, user.FullName, event.Title, waitlistCount); err != nil {
+			position, _ := u.registrationRepo.GetWaitlistPosition(ctx, registration.ID)
+			if err := u.emailSender.SendWaitlistNotification(user.Email, user.FullName, event.Title, position); err != nil {
 				// Log error but don't fail
 				fmt.Printf("Failed to send waitlist notification: %v\n", err)
 			}
@@ -141,7 +165,7 @@ func (u *registrationUsecase) CancelRegistration(ctx context.Context, userID, re
 
 	// Check if can cancel
 	if !registration.CanCancel() {
-		return fmt.Errorf("cannot cancel this registration")
+		return errCannotCancel
 	}
 
 	// Get event
@@ -156,62 +180,284 @@ func (u *registrationUsecase) CancelRegistration(ctx context.Context, userID, re
 		return fmt.Errorf("user not found")
 	}
 
-	wasRegistered := registration.IsRegistered()
+	if err := u.cancelAndPromote(ctx, registration, event); err != nil {
+		return err
+	}
 
-	// Cancel registration
-	if err := u.registrationRepo.Cancel(ctx, registrationID); err != nil {
-		return fmt.Errorf("failed to cancel registration: %w", err)
+	// Send cancellation email
+	if u.emailSender != nil {
+		if err := u.emailSender.SendCancellationConfirmation(user.Email, user.FullName, event.Title); err != nil {
+			fmt.Printf("Failed to send cancellation email: %v\n", err)
+		}
 	}
 
-	// If was registered (not waitlisted), decrement count and promote from waitlist
-	if wasRegistered {
-		if err := u.eventRepo.DecrementParticipants(ctx, registration.EventID); err != nil {
-			return fmt.Errorf("failed to update participant count: %w", err)
+	return nil
+}
+
+func (u *registrationUsecase) CancelUpcomingRegistrations(ctx context.Context, userID uuid.UUID) (int, error) {
+	registrations, _, err := u.registrationRepo.GetByUser(ctx, userID, repository.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get registrations: %w", err)
+	}
+
+	cancelled := 0
+	for i := range registrations {
+		registration := &registrations[i]
+		if !registration.CanCancel() {
+			continue
 		}
 
-		// Promote first person from waitlist
-		promoted, err := u.registrationRepo.PromoteFromWaitlist(ctx, registration.EventID)
+		event, err := u.eventRepo.GetByID(ctx, registration.EventID)
 		if err != nil {
-			fmt.Printf("Failed to promote from waitlist: %v\n", err)
+			fmt.Printf("Failed to get event %s for registration %s: %v\n", registration.EventID, registration.ID, err)
+			continue
 		}
 
-		if promoted != nil {
-			// Increment participant count for promoted person
-			if err := u.eventRepo.IncrementParticipants(ctx, registration.EventID); err != nil {
-				fmt.Printf("Failed to increment participants for promoted registration: %v\n", err)
-			}
+		// Past and running events are left alone, their attendance record matters
+		if !event.StartDate.After(time.Now()) {
+			continue
+		}
 
-			// Send promotion email
-			promotedUser, err := u.userRepo.GetByID(ctx, promoted.UserID)
-			if err == nil && u.emailSender != nil {
-				zoomLink := ""
-				if event.ZoomLink != nil {
-					zoomLink = *event.ZoomLink
-				}
-				if err := u.emailSender.SendWaitlistPromotion(promotedUser.Email, promotedUser.FullName, event.Title, event.StartDate, zoomLink); err != nil {
-					fmt.Printf("Failed to send promotion email: %v\n", err)
-				}
+		if err := u.cancelAndPromote(ctx, registration, event); err != nil {
+			// Cancelled or expired since it was listed, nothing left to do
+			if errors.Is(err, errCannotCancel) {
+				continue
 			}
+			return cancelled, err
 		}
+		cancelled++
 	}
 
-	// Send cancellation email
-	if u.emailSender != nil {
-		if err := u.emailSender.SendCancellationConfirmation(user.Email, user.FullName, event.Title); err != nil {
-			fmt.Printf("Failed to send cancellation email: %v\n", err)
+	return cancelled, nil
+}
+
+// cancelAndPromote cancels a registration and, if it held a seat, hands the seat
+// to the first person on the waitlist. The database changes are applied atomically.
+func (u *registrationUsecase) cancelAndPromote(ctx context.Context, registration *domain.Registration, event *domain.Event) error {
+	var next *domain.Registration
+	err := u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		// Lock the event first, like CreateWithSeat, so no registration is
+		// waitlisted against the old count while the seat is passed on
+		locked, err := repos.Events.GetByIDForUpdate(ctx, event.ID)
+		if err != nil {
+			return err
 		}
+
+		// Decide on the locked row, a concurrent cancel or offer expiry may have
+		// changed the registration since it was read
+		current, err := repos.Registrations.GetByIDForUpdate(ctx, registration.ID)
+		if err != nil {
+			return err
+		}
+
+		if !current.CanCancel() {
+			return errCannotCancel
+		}
+
+		// Cancel registration
+		if err := repos.Registrations.Cancel(ctx, current.ID); err != nil {
+			return fmt.Errorf("failed to cancel registration: %w", err)
+		}
+
+		// Waitlisted registrations did not hold a seat
+		if !current.HoldsSeat() {
+			return nil
+		}
+
+		next, err = u.releaseSeat(ctx, repos, locked)
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
+	u.notifySeatPassedOn(ctx, event, next)
+
 	return nil
 }
 
-func (u *registrationUsecase) GetMyRegistrations(ctx context.Context, userID uuid.UUID) ([]domain.Registration, error) {
-	registrations, err := u.registrationRepo.GetByUser(ctx, userID)
+// releaseSeat passes a freed seat of the event to the first person on the
+// waitlist, or gives it back when nobody is waiting. In offer mode the seat is
+// held for them until the claim deadline. Returns who the seat went to, if anyone.
+func (u *registrationUsecase) releaseSeat(ctx context.Context, repos repository.Repositories, event *domain.Event) (*domain.Registration, error) {
+	var next *domain.Registration
+	var err error
+
+	// Offers are pointless once the event has started
+	if u.waitlistPolicy.OfferMode && !event.HasStarted() {
+		expiresAt := time.Now().Add(u.waitlistPolicy.OfferTTL)
+		if expiresAt.After(event.StartDate) {
+			expiresAt = event.StartDate
+		}
+		next, err = repos.Registrations.OfferFromWaitlist(ctx, event.ID, expiresAt)
+	} else {
+		next, err = repos.Registrations.PromoteFromWaitlist(ctx, event.ID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get registrations: %w", err)
+		return nil, err
 	}
 
-	return registrations, nil
+	// The seat stays taken when it was passed on
+	if next == nil {
+		if err := repos.Events.DecrementParticipants(ctx, event.ID); err != nil {
+			return nil, fmt.Errorf("failed to update participant count: %w", err)
+		}
+	}
+
+	return next, nil
+}
+
+// notifySeatPassedOn emails the user a freed seat was promoted or offered to
+func (u *registrationUsecase) notifySeatPassedOn(ctx context.Context, event *domain.Event, next *domain.Registration) {
+	if next == nil || u.emailSender == nil {
+		return
+	}
+
+	nextUser, err := u.userRepo.GetByID(ctx, next.UserID)
+	if err != nil {
+		fmt.Printf("Failed to get user %s for waitlist email: %v\n", next.UserID, err)
+		return
+	}
+
+	if next.IsOffered() {
+		if err := u.emailSender.SendWaitlistOffer(nextUser.Email, nextUser.FullName, event.Title, event.StartDate, *next.OfferExpiresAt, next.ID.String()); err != nil {
+			fmt.Printf("Failed to send offer email: %v\n", err)
+		}
+		return
+	}
+
+	if err := u.emailSender.SendWaitlistPromotion(nextUser.Email, nextUser.FullName, event.Title, event.StartDate, next.ID.String()); err != nil {
+		fmt.Printf("Failed to send promotion email: %v\n", err)
+	}
+}
+
+func (u *registrationUsecase) ClaimOffer(ctx context.Context, userID, registrationID uuid.UUID) (*domain.Registration, error) {
+	registration, err := u.registrationRepo.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	// Check ownership
+	if registration.UserID != userID {
+		return nil, fmt.Errorf("you don't have permission to claim this registration")
+	}
+
+	if !registration.IsOffered() {
+		return nil, fmt.Errorf("no seat is offered for this registration")
+	}
+
+	// The seat was already counted when it was offered
+	if err := u.registrationRepo.ClaimOffer(ctx, registrationID); err != nil {
+		return nil, err
+	}
+	registration.Status = domain.RegistrationStatusRegistered
+	registration.OfferExpiresAt = nil
+
+	// Send confirmation email
+	if u.emailSender != nil {
+		event, err := u.eventRepo.GetByID(ctx, registration.EventID)
+		user, userErr := u.userRepo.GetByID(ctx, userID)
+		if err == nil && userErr == nil {
+			checkInToken := utils.GenerateCheckInToken(registration.ID, registration.EventID, u.checkInSecret)
+			if err := u.emailSender.SendRegistrationConfirmation(user.Email, user.FullName, event.Title, event.StartDate, registration.ID.String(), checkInToken, eventCalendar(event)); err != nil {
+				fmt.Printf("Failed to send confirmation email: %v\n", err)
+			}
+		}
+	}
+
+	return registration, nil
+}
+
+// GetCheckInQRCode renders the check-in QR code of a registration as PNG
+func (u *registrationUsecase) GetCheckInQRCode(ctx context.Context, userID, registrationID uuid.UUID) ([]byte, error) {
+	registration, err := u.registrationRepo.GetByID(ctx, registrationID)
+	if err != nil {
+		return nil, fmt.Errorf("registration not found")
+	}
+
+	// Check ownership
+	if registration.UserID != userID {
+		return nil, fmt.Errorf("you don't have permission to view this registration")
+	}
+
+	if !registration.IsRegistered() {
+		return nil, fmt.Errorf("registration is not active")
+	}
+
+	checkInToken := utils.GenerateCheckInToken(registration.ID, registration.EventID, u.checkInSecret)
+	png, err := utils.GenerateCheckInQRCode(checkInToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	return png, nil
+}
+
+// ExpireWaitlistOffers cancels offers past their claim deadline and passes each
+// seat on to the next person on the waitlist. Returns the number of expired offers.
+func (u *registrationUsecase) ExpireWaitlistOffers(ctx context.Context) (int, error) {
+	offers, err := u.registrationRepo.GetExpiredOffers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired offers: %w", err)
+	}
+
+	expired := 0
+	for i := range offers {
+		offer := &offers[i]
+
+		event, err := u.eventRepo.GetByID(ctx, offer.EventID)
+		if err != nil {
+			fmt.Printf("Failed to get event %s for offer %s: %v\n", offer.EventID, offer.ID, err)
+			continue
+		}
+
+		var next *domain.Registration
+		var lapsed bool
+		err = u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+			// Lock the event before touching the waitlist, see cancelAndPromote
+			locked, err := repos.Events.GetByIDForUpdate(ctx, offer.EventID)
+			if err != nil {
+				return err
+			}
+
+			// Skip offers claimed or cancelled since they were listed
+			lapsed, err = repos.Registrations.ExpireOffer(ctx, offer.ID)
+			if err != nil || !lapsed {
+				return err
+			}
+
+			next, err = u.releaseSeat(ctx, repos, locked)
+			return err
+		})
+		if err != nil {
+			fmt.Printf("Failed to expire offer %s: %v\n", offer.ID, err)
+			continue
+		}
+		if !lapsed {
+			continue
+		}
+		expired++
+
+		if u.emailSender != nil {
+			if user, err := u.userRepo.GetByID(ctx, offer.UserID); err == nil {
+				if err := u.emailSender.SendWaitlistOfferExpired(user.Email, user.FullName, event.Title); err != nil {
+					fmt.Printf("Failed to send offer expiry email: %v\n", err)
+				}
+			}
+		}
+		u.notifySeatPassedOn(ctx, event, next)
+	}
+
+	return expired, nil
+}
+
+func (u *registrationUsecase) GetMyRegistrations(ctx context.Context, userID uuid.UUID, opts repository.ListOptions) ([]domain.Registration, *response.PaginationMeta, error) {
+	registrations, page, err := u.registrationRepo.GetByUser(ctx, userID, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get registrations: %w", err)
+	}
+
+	return registrations, response.NewPaginationMeta(opts.Page, opts.Limit, page.TotalItems, page.NextCursor), nil
 }
 
 func (u *registrationUsecase) GetEventRegistrations(ctx context.Context, organizerID, eventID uuid.UUID) ([]domain.Registration, error) {
@@ -233,3 +479,24 @@ func (u *registrationUsecase) GetEventRegistrations(ctx context.Context, organiz
 
 	return registrations, nil
 }
+
+// ExportEventRegistrations streams every registration of an event with contact details
+// and attendance to fn, in registration order
+func (u *registrationUsecase) ExportEventRegistrations(ctx context.Context, organizerID, eventID uuid.UUID, fn func(*domain.Registration) error) error {
+	// Get event
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("event not found")
+	}
+
+	// Check ownership
+	if event.OrganizerID != organizerID {
+		return fmt.Errorf("you don't have permission to view registrations for this event")
+	}
+
+	if err := u.registrationRepo.StreamByEvent(ctx, eventID, false, fn); err != nil {
+		return fmt.Errorf("failed to export registrations: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestRegistrationUsecase(db *sql.DB, txManager repository.TxManager) *registrationUsecase {
+	return NewRegistrationUsecase(
+		repository.NewRegistrationRepository(db),
+		repository.NewEventRepository(db),
+		repository.NewUserRepository(db),
+		txManager,
+		nil,
+		true,
+		WaitlistPolicy{OfferTTL: 24 * time.Hour},
+		"test-secret",
+	).(*registrationUsecase)
+}
+
+// registerUser registers a new user for the event and returns the registration
+func registerUser(t *testing.T, db *sql.DB, uc *registrationUsecase, eventID uuid.UUID) *domain.Registration {
+	t.Helper()
+
+	user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+	registration, err := uc.RegisterForEvent(context.Background(), user.ID, eventID)
+	if err != nil {
+		t.Fatalf("RegisterForEvent: %v", err)
+	}
+
+	return registration
+}
+
+func getRegistration(t *testing.T, uc *registrationUsecase, id uuid.UUID) *domain.Registration {
+	t.Helper()
+
+	registration, err := uc.registrationRepo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	return registration
+}
+
+func getEvent(t *testing.T, uc *registrationUsecase, id uuid.UUID) *domain.Event {
+	t.Helper()
+
+	event, err := uc.eventRepo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	return event
+}
+
+func TestConcurrentCancelReleasesSeatOnce(t *testing.T) {
+	db := testutil.DB(t)
+	uc := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 1)
+
+	seated := registerUser(t, db, uc, event.ID)
+	first := registerUser(t, db, uc, event.ID)
+	second := registerUser(t, db, uc, event.ID)
+	if !seated.IsRegistered() || !first.IsWaitlist() || !second.IsWaitlist() {
+		t.Fatalf("unexpected statuses %s, %s, %s", seated.Status, first.Status, second.Status)
+	}
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := uc.CancelRegistration(context.Background(), seated.UserID, seated.ID); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("%d cancellations succeeded, want 1", succeeded)
+	}
+
+	// The seat went to the first waitlisted user only
+	if got := getRegistration(t, uc, first.ID); !got.IsRegistered() {
+		t.Fatalf("first waitlisted registration is %s, want registered", got.Status)
+	}
+	if got := getRegistration(t, uc, second.ID); !got.IsWaitlist() {
+		t.Fatalf("second waitlisted registration is %s, want waitlist", got.Status)
+	}
+	if got := getEvent(t, uc, event.ID); got.CurrentParticipants != 1 {
+		t.Fatalf("current_participants = %d, want 1", got.CurrentParticipants)
+	}
+}
+
+func TestCancelWaitlistedKeepsSeats(t *testing.T) {
+	db := testutil.DB(t)
+	uc := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 1)
+
+	registerUser(t, db, uc, event.ID)
+	waitlisted := registerUser(t, db, uc, event.ID)
+
+	if err := uc.CancelRegistration(context.Background(), waitlisted.UserID, waitlisted.ID); err != nil {
+		t.Fatalf("CancelRegistration: %v", err)
+	}
+	if err := uc.CancelRegistration(context.Background(), waitlisted.UserID, waitlisted.ID); err == nil {
+		t.Fatal("cancelling a cancelled registration succeeded")
+	}
+
+	if got := getEvent(t, uc, event.ID); got.CurrentParticipants != 1 {
+		t.Fatalf("current_participants = %d, want 1", got.CurrentParticipants)
+	}
+}
+
+var errInjected = errors.New("injected failure")
+
+// failingTxManager runs transactions on the real database with repositories
+// swapped by wrap, to make a step inside the transaction fail
+type failingTxManager struct {
+	repository.TxManager
+	wrap func(repos repository.Repositories) repository.Repositories
+}
+
+func (m *failingTxManager) WithinTx(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return m.TxManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		return fn(m.wrap(repos))
+	})
+}
+
+// failingPromotionRepository fails to take users off the waitlist
+type failingPromotionRepository struct {
+	repository.RegistrationRepository
+}
+
+func (r *failingPromotionRepository) PromoteFromWaitlist(ctx context.Context, eventID uuid.UUID) (*domain.Registration, error) {
+	return nil, errInjected
+}
+
+func (r *failingPromotionRepository) OfferFromWaitlist(ctx context.Context, eventID uuid.UUID, expiresAt time.Time) (*domain.Registration, error) {
+	return nil, errInjected
+}
+
+// failingDecrementRepository fails to give seats back
+type failingDecrementRepository struct {
+	repository.EventRepository
+}
+
+func (r *failingDecrementRepository) DecrementParticipants(ctx context.Context, id uuid.UUID) error {
+	return errInjected
+}
+
+func failPromotion(db *sql.DB) repository.TxManager {
+	return &failingTxManager{
+		TxManager: repository.NewTxManager(db),
+		wrap: func(repos repository.Repositories) repository.Repositories {
+			repos.Registrations = &failingPromotionRepository{RegistrationRepository: repos.Registrations}
+			return repos
+		},
+	}
+}
+
+func failDecrement(db *sql.DB) repository.TxManager {
+	return &failingTxManager{
+		TxManager: repository.NewTxManager(db),
+		wrap: func(repos repository.Repositories) repository.Repositories {
+			repos.Events = &failingDecrementRepository{EventRepository: repos.Events}
+			return repos
+		},
+	}
+}
+
+func TestCancelRollsBackWhenPromotionFails(t *testing.T) {
+	db := testutil.DB(t)
+	setup := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 1)
+
+	seated := registerUser(t, db, setup, event.ID)
+	waitlisted := registerUser(t, db, setup, event.ID)
+
+	uc := newTestRegistrationUsecase(db, failPromotion(db))
+	if err := uc.CancelRegistration(context.Background(), seated.UserID, seated.ID); !errors.Is(err, errInjected) {
+		t.Fatalf("CancelRegistration error = %v, want injected failure", err)
+	}
+
+	if got := getRegistration(t, uc, seated.ID); !got.IsRegistered() {
+		t.Fatalf("cancelled registration is %s after rollback, want registered", got.Status)
+	}
+	if got := getRegistration(t, uc, waitlisted.ID); !got.IsWaitlist() {
+		t.Fatalf("waitlisted registration is %s after rollback, want waitlist", got.Status)
+	}
+	if got := getEvent(t, uc, event.ID); got.CurrentParticipants != 1 {
+		t.Fatalf("current_participants = %d after rollback, want 1", got.CurrentParticipants)
+	}
+}
+
+func TestCancelRollsBackWhenSeatReleaseFails(t *testing.T) {
+	db := testutil.DB(t)
+	setup := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 2)
+
+	seated := registerUser(t, db, setup, event.ID)
+
+	uc := newTestRegistrationUsecase(db, failDecrement(db))
+	if err := uc.CancelRegistration(context.Background(), seated.UserID, seated.ID); !errors.Is(err, errInjected) {
+		t.Fatalf("CancelRegistration error = %v, want injected failure", err)
+	}
+
+	if got := getRegistration(t, uc, seated.ID); !got.IsRegistered() {
+		t.Fatalf("cancelled registration is %s after rollback, want registered", got.Status)
+	}
+	if got := getEvent(t, uc, event.ID); got.CurrentParticipants != 1 {
+		t.Fatalf("current_participants = %d after rollback, want 1", got.CurrentParticipants)
+	}
+}
+
+func TestExpireOfferRollsBackWhenSeatReleaseFails(t *testing.T) {
+	db := testutil.DB(t)
+	setup := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, 1)
+
+	// An offer whose claim deadline has passed holds the only seat
+	offered := registerUser(t, db, setup, event.ID)
+	_, err := db.ExecContext(context.Background(), `
+		UPDATE registrations SET status = $1, offer_expires_at = $2 WHERE id = $3
+	`, domain.RegistrationStatusOffered, time.Now().Add(-time.Minute), offered.ID)
+	if err != nil {
+		t.Fatalf("failed to offer seat: %v", err)
+	}
+
+	uc := newTestRegistrationUsecase(db, failDecrement(db))
+	if _, err := uc.ExpireWaitlistOffers(context.Background()); err != nil {
+		t.Fatalf("ExpireWaitlistOffers: %v", err)
+	}
+
+	if got := getRegistration(t, uc, offered.ID); !got.IsOffered() {
+		t.Fatalf("offer is %s after rollback, want offered", got.Status)
+	}
+	if got := getEvent(t, uc, event.ID); got.CurrentParticipants != 1 {
+		t.Fatalf("current_participants = %d after rollback, want 1", got.CurrentParticipants)
+	}
+}
+
+func TestCancelAndRegisterConcurrentlyStrandsNoWaitlister(t *testing.T) {
+	db := testutil.DB(t)
+	db.SetMaxOpenConns(20)
+	ctx := context.Background()
+	uc := newTestRegistrationUsecase(db, repository.NewTxManager(db))
+
+	const maxParticipants = 5
+	const newcomers = 20
+
+	organizer := testutil.CreateUser(t, db, domain.RoleOrganisasi)
+	event := testutil.CreateEvent(t, db, organizer.ID, maxParticipants)
+
+	var seated []*domain.Registration
+	for i := 0; i < maxParticipants; i++ {
+		seated = append(seated, registerUser(t, db, uc, event.ID))
+	}
+	var newcomerIDs []uuid.UUID
+	for i := 0; i < newcomers; i++ {
+		newcomerIDs = append(newcomerIDs, testutil.CreateUser(t, db, domain.RoleMahasiswa).ID)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, maxParticipants+newcomers)
+	for _, registration := range seated {
+		wg.Add(1)
+		go func(registration *domain.Registration) {
+			defer wg.Done()
+			errs <- uc.CancelRegistration(ctx, registration.UserID, registration.ID)
+		}(registration)
+	}
+	for _, userID := range newcomerIDs {
+		wg.Add(1)
+		go func(userID uuid.UUID) {
+			defer wg.Done()
+			_, err := uc.RegisterForEvent(ctx, userID, event.ID)
+			errs <- err
+		}(userID)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent cancel or registration failed: %v", err)
+		}
+	}
+
+	registered, err := uc.registrationRepo.CountByEventAndStatus(ctx, event.ID, domain.RegistrationStatusRegistered)
+	if err != nil {
+		t.Fatalf("CountByEventAndStatus: %v", err)
+	}
+	waitlisted, err := uc.registrationRepo.CountByEventAndStatus(ctx, event.ID, domain.RegistrationStatusWaitlist)
+	if err != nil {
+		t.Fatalf("CountByEventAndStatus: %v", err)
+	}
+
+	current := getEvent(t, uc, event.ID).CurrentParticipants
+	if registered != current {
+		t.Errorf("%d registered rows, current_participants = %d", registered, current)
+	}
+	if waitlisted > 0 && current < maxParticipants {
+		t.Errorf("%d users waitlisted next to %d free seats", waitlisted, maxParticipants-current)
+	}
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/dto/request"
+	"event-campus-backend/internal/dto/response"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/utils"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserUsecase defines interface for user profile business logic
+type UserUsecase interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req *request.UpdateProfileRequest) (*response.UserResponse, error)
+	ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, req *request.ChangePasswordRequest) error
+	UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarPath string) (*response.UserResponse, *string, error)
+}
+
+type userUsecase struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	baseURL     string
+}
+
+// NewUserUsecase creates a new user usecase
+func NewUserUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	baseURL string,
+) UserUsecase {
+	return &userUsecase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		baseURL:     baseURL,
+	}
+}
+
+func (u *userUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*response.UserResponse, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *userUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *request.UpdateProfileRequest) (*response.UserResponse, error) {
+	// Validate phone number
+	if !utils.IsValidPhone(req.PhoneNumber) {
+		return nil, fmt.Errorf("invalid phone number format")
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	user.FullName = req.FullName
+	user.PhoneNumber = utils.NormalizePhone(req.PhoneNumber)
+
+	if err := u.userRepo.UpdateProfile(ctx, userID, user.FullName, user.PhoneNumber); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, nil
+}
+
+func (u *userUsecase) ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, req *request.ChangePasswordRequest) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+
+	// Verify old password
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.OldPassword)); err != nil {
+		return fmt.Errorf("old password is incorrect")
+	}
+
+	if req.OldPassword == req.NewPassword {
+		return fmt.Errorf("new password must be different from the old password")
+	}
+
+	// Hash password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := u.userRepo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+
+	// Keep the current session, sign out every other device
+	if err := u.sessionRepo.RevokeAllByUserExcept(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAvatar stores the new avatar path and returns the path it replaced so the caller can delete the old file
+func (u *userUsecase) UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarPath string) (*response.UserResponse, *string, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("user not found")
+	}
+
+	// The replaced path comes from the update itself, so concurrent uploads each
+	// delete the file they replaced
+	oldAvatarPath, err := u.userRepo.UpdateAvatar(ctx, userID, avatarPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+	user.AvatarPath = &avatarPath
+
+	resp := response.ToProfileResponse(user, u.baseURL)
+	return &resp, oldAvatarPath, nil
+}
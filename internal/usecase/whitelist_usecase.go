@@ -24,6 +24,7 @@ type WhitelistUsecase interface {
 type whitelistUsecase struct {
 	whitelistRepo repository.WhitelistRepository
 	userRepo      repository.UserRepository
+	txManager     repository.TxManager
 	emailSender   *utils.EmailSender
 	baseURL       string
 }
@@ -32,12 +33,14 @@ type whitelistUsecase struct {
 func NewWhitelistUsecase(
 	whitelistRepo repository.WhitelistRepository,
 	userRepo repository.UserRepository,
+	txManager repository.TxManager,
 	emailSender *utils.EmailSender,
 	baseURL string,
 ) WhitelistUsecase {
 	return &whitelistUsecase{
 		whitelistRepo: whitelistRepo,
 		userRepo:      userRepo,
+		txManager:     txManager,
 		emailSender:   emailSender,
 		baseURL:       baseURL,
 	}
@@ -90,15 +93,7 @@ func (u *whitelistUsecase) GetMyRequest(ctx context.Context, userID uuid.UUID) (
 		return nil, nil
 	}
 
-	// Get user info
-	user, err := u.userRepo.GetByID(ctx, request.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
-	}
-
 	resp := response.ToWhitelistRequestResponse(request, u.baseURL)
-	resp.UserName = user.FullName
-	resp.UserEmail = user.Email
 
 	return &resp, nil
 }
@@ -109,18 +104,10 @@ func (u *whitelistUsecase) GetAllRequests(ctx context.Context, status string) ([
 		return nil, fmt.Errorf("failed to get requests: %w", err)
 	}
 
+	// Requester and reviewer names are joined in by the repository
 	var responses []response.WhitelistRequestResponse
 	for _, req := range requests {
-		user, err := u.userRepo.GetByID(ctx, req.UserID)
-		if err != nil {
-			continue // Skip if user not found
-		}
-
-		resp := response.ToWhitelistRequestResponse(&req, u.baseURL)
-		resp.UserName = user.FullName
-		resp.UserEmail = user.Email
-
-		responses = append(responses, resp)
+		responses = append(responses, response.ToWhitelistRequestResponse(&req, u.baseURL))
 	}
 
 	return responses, nil
@@ -162,16 +149,26 @@ func (u *whitelistUsecase) ReviewRequest(ctx context.Context, requestID uuid.UUI
 		adminNotesStr = *req.AdminNotes
 	}
 
-	if err := u.whitelistRepo.UpdateStatus(ctx, requestID, newStatus, adminNotesStr, reviewerID); err != nil {
-		return fmt.Errorf("failed to update request: %w", err)
-	}
+	// The request status and the role change are applied together
+	err = u.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if err := repos.Whitelist.UpdateStatus(ctx, requestID, newStatus, adminNotesStr, reviewerID); err != nil {
+			return fmt.Errorf("failed to update request: %w", err)
+		}
 
-	// If approved, update user role to organisasi
-	if req.Approved {
-		if err := u.userRepo.UpdateRole(ctx, user.ID, domain.RoleOrganisasi, true); err != nil {
-			return fmt.Errorf("failed to update user role: %w", err)
+		// If approved, update user role to organisasi
+		if req.Approved {
+			if err := repos.Users.UpdateRole(ctx, user.ID, domain.RoleOrganisasi, true); err != nil {
+				return fmt.Errorf("failed to update user role: %w", err)
+			}
 		}
 
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.Approved {
 		// Send approval email
 		if u.emailSender != nil {
 			if err := u.emailSender.SendWhitelistApproval(user.Email, user.FullName, whitelistRequest.OrganizationName); err != nil {
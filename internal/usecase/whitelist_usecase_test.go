@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"event-campus-backend/internal/domain"
+	"event-campus-backend/internal/repository"
+	"event-campus-backend/internal/testutil"
+	"testing"
+)
+
+func TestWhitelistListingQueryCountIsConstant(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+
+	counting := testutil.NewCountingDB(db)
+	uc := NewWhitelistUsecase(
+		repository.NewWhitelistRepository(counting),
+		repository.NewUserRepository(counting),
+		repository.NewTxManager(db),
+		nil,
+		"http://localhost:8080",
+	)
+
+	whitelistRepo := repository.NewWhitelistRepository(db)
+	addRequests := func(n int) {
+		for i := 0; i < n; i++ {
+			user := testutil.CreateUser(t, db, domain.RoleMahasiswa)
+			request := &domain.WhitelistRequest{
+				UserID:           user.ID,
+				OrganizationName: "Test Organization",
+				DocumentPath:     "uploads/documents/test.pdf",
+			}
+			if err := whitelistRepo.Create(ctx, request); err != nil {
+				t.Fatalf("failed to create whitelist request: %v", err)
+			}
+		}
+	}
+
+	addRequests(1)
+	counting.Reset()
+	if _, err := uc.GetPendingRequests(ctx); err != nil {
+		t.Fatalf("GetPendingRequests: %v", err)
+	}
+	want := counting.Queries()
+
+	addRequests(20)
+	counting.Reset()
+	if _, err := uc.GetPendingRequests(ctx); err != nil {
+		t.Fatalf("GetPendingRequests: %v", err)
+	}
+	if got := counting.Queries(); got != want {
+		t.Errorf("%d queries after adding 20 requests, want %d", got, want)
+	}
+}
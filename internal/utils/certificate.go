@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// certificateSerialBytes is the randomness in a certificate serial number, 10 base32 characters
+const certificateSerialBytes = 6
+
+// certificateHashSize is the truncated HMAC-SHA256 printed on certificates, 32 hex characters
+const certificateHashSize = 16
+
+// certificateFont is a PDF core font, so certificates need no font files
+const certificateFont = "Helvetica"
+
+// CertificateText is a text placed on a certificate page. X and Y are millimetres from the
+// top-left corner, Y being the baseline; Align is left, center or right of X.
+type CertificateText struct {
+	Text     string
+	X        float64
+	Y        float64
+	FontSize float64
+	Bold     bool
+	Color    string
+	Align    string
+}
+
+// GenerateCertificateSerial creates a hard to guess serial number like EC-2026-K3QZ7M2XFA
+func GenerateCertificateSerial(issuedAt time.Time) (string, error) {
+	b := make([]byte, certificateSerialBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate certificate serial: %w", err)
+	}
+
+	return fmt.Sprintf("EC-%d-%s", issuedAt.Year(), base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)), nil
+}
+
+// GenerateCertificateHash signs what a certificate attests. The hash is printed on the
+// certificate, so one whose name or serial number was altered no longer matches the hash
+// that verification reports. It is formatted in groups of four, like 3F2A-9C1B-...
+func GenerateCertificateHash(serialNumber, participantName string, registrationID, eventID uuid.UUID, secret string) string {
+	hash := strings.ToUpper(hex.EncodeToString(certificateSignature(serialNumber, participantName, registrationID, eventID, secret)))
+
+	groups := make([]string, 0, len(hash)/4)
+	for i := 0; i < len(hash); i += 4 {
+		groups = append(groups, hash[i:i+4])
+	}
+	return strings.Join(groups, "-")
+}
+
+// ValidateCertificateHash checks a hash as read off a certificate, in any case and with or
+// without the dashes and spaces between groups
+func ValidateCertificateHash(hash, serialNumber, participantName string, registrationID, eventID uuid.UUID, secret string) bool {
+	hash = strings.NewReplacer("-", "", " ", "").Replace(hash)
+
+	signature, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(signature, certificateSignature(serialNumber, participantName, registrationID, eventID, secret))
+}
+
+// certificateSignature signs a certificate, keyed apart from other uses of the secret
+func certificateSignature(serialNumber, participantName string, registrationID, eventID uuid.UUID, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte("certificate:"+secret))
+	mac.Write([]byte(serialNumber))
+	mac.Write([]byte{0})
+	mac.Write([]byte(participantName))
+	mac.Write([]byte{0})
+	mac.Write(registrationID[:])
+	mac.Write(eventID[:])
+	return mac.Sum(nil)[:certificateHashSize]
+}
+
+// ParseHexColor parses a #RRGGBB color
+func ParseHexColor(color string) (r, g, b int, err error) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, fmt.Errorf("invalid color %q, use #RRGGBB", color)
+	}
+
+	value, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid color %q, use #RRGGBB", color)
+	}
+
+	return int(value >> 16 & 0xff), int(value >> 8 & 0xff), int(value & 0xff), nil
+}
+
+// GenerateCertificatePDF renders a single-page certificate of width x height millimetres
+// with the JPG or PNG at backgroundPath stretched over the page and texts on top
+func GenerateCertificatePDF(backgroundPath string, width, height float64, texts []CertificateText) ([]byte, error) {
+	orientation := "P"
+	if width > height {
+		orientation = "L"
+	}
+
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: width, Ht: height},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	pdf.ImageOptions(backgroundPath, 0, 0, width, height, false, gofpdf.ImageOptions{}, 0, "")
+
+	// Core fonts are cp1252 encoded, which covers Indonesian names
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for _, text := range texts {
+		style := ""
+		if text.Bold {
+			style = "B"
+		}
+		pdf.SetFont(certificateFont, style, text.FontSize)
+
+		r, g, b := 0, 0, 0
+		if text.Color != "" {
+			var err error
+			if r, g, b, err = ParseHexColor(text.Color); err != nil {
+				return nil, err
+			}
+		}
+		pdf.SetTextColor(r, g, b)
+
+		s := translate(text.Text)
+		x := text.X
+		switch strings.ToLower(text.Align) {
+		case "left":
+		case "right":
+			x -= pdf.GetStringWidth(s)
+		default:
+			x -= pdf.GetStringWidth(s) / 2
+		}
+
+		pdf.Text(x, text.Y, s)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+// ErrInvalidCheckInToken is returned for check-in tokens that are malformed or not signed by us
+var ErrInvalidCheckInToken = errors.New("invalid check-in token")
+
+const (
+	checkInPayloadSize   = 32 // registration ID + event ID
+	checkInSignatureSize = 16 // truncated HMAC-SHA256, plenty for a token that cannot be brute-forced online
+	checkInQRCodeSize    = 256
+)
+
+// EventCheckInCodePeriod is how long each rotating self check-in code is shown for
+const EventCheckInCodePeriod = 30 * time.Second
+
+// eventCheckInCodeDigits is the length of a rotating self check-in code
+const eventCheckInCodeDigits = 6
+
+// GenerateCheckInToken signs the registration and event IDs into a compact token for a check-in QR code
+func GenerateCheckInToken(registrationID, eventID uuid.UUID, secret string) string {
+	payload := make([]byte, 0, checkInPayloadSize+checkInSignatureSize)
+	payload = append(payload, registrationID[:]...)
+	payload = append(payload, eventID[:]...)
+
+	return base64.RawURLEncoding.EncodeToString(append(payload, checkInSignature(payload, secret)...))
+}
+
+// ValidateCheckInToken verifies a check-in token and returns the registration and event IDs it was issued for
+func ValidateCheckInToken(token, secret string) (registrationID, eventID uuid.UUID, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != checkInPayloadSize+checkInSignatureSize {
+		return uuid.Nil, uuid.Nil, ErrInvalidCheckInToken
+	}
+
+	payload, signature := raw[:checkInPayloadSize], raw[checkInPayloadSize:]
+	if !hmac.Equal(signature, checkInSignature(payload, secret)) {
+		return uuid.Nil, uuid.Nil, ErrInvalidCheckInToken
+	}
+
+	copy(registrationID[:], payload[:16])
+	copy(eventID[:], payload[16:])
+
+	return registrationID, eventID, nil
+}
+
+// GenerateCheckInQRCode renders a check-in token as a PNG QR code
+func GenerateCheckInQRCode(token string) ([]byte, error) {
+	return qrcode.Encode(token, qrcode.Medium, checkInQRCodeSize)
+}
+
+// GenerateEventCheckInCode derives the self check-in code of an event shown at t.
+// Codes are TOTP-style: they change every EventCheckInCodePeriod and differ per event.
+func GenerateEventCheckInCode(eventID uuid.UUID, secret string, t time.Time) string {
+	return eventCheckInCode(eventID, secret, t.Unix()/int64(EventCheckInCodePeriod/time.Second))
+}
+
+// ValidateEventCheckInCode checks a self check-in code submitted at t. The code of the
+// previous period is accepted as well, for participants who typed it as it rotated.
+func ValidateEventCheckInCode(code string, eventID uuid.UUID, secret string, t time.Time) bool {
+	step := t.Unix() / int64(EventCheckInCodePeriod/time.Second)
+	for _, s := range []int64{step, step - 1} {
+		if hmac.Equal([]byte(code), []byte(eventCheckInCode(eventID, secret, s))) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventCheckInCode computes the code of an event for a time step with HOTP dynamic truncation (RFC 4226)
+func eventCheckInCode(eventID uuid.UUID, secret string, step int64) string {
+	mac := hmac.New(sha256.New, []byte("self-check-in:"+secret))
+	mac.Write(eventID[:])
+	_ = binary.Write(mac, binary.BigEndian, step)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", eventCheckInCodeDigits, value%1000000)
+}
+
+// checkInSignature signs a check-in payload, keyed apart from other uses of the secret
+func checkInSignature(payload []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte("check-in:"+secret))
+	mac.Write(payload)
+	return mac.Sum(nil)[:checkInSignatureSize]
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io"
 	"time"
 
 	"gopkg.in/gomail.v2"
@@ -27,14 +28,58 @@ func NewEmailSender(host string, port int, user, password string) *EmailSender {
 	}
 }
 
+// checkInQRCodeName is the inline image email bodies reference as cid:checkin-qr.png
+const checkInQRCodeName = "checkin-qr.png"
+
+// calendarAttachmentName is the iCalendar file attached to emails about an event
+const calendarAttachmentName = "event.ics"
+
 // SendEmail sends an email
 func (e *EmailSender) SendEmail(to, subject, htmlBody string) error {
+	return e.send(e.newMessage(to, subject, htmlBody))
+}
+
+// sendWithCheckInQRCode sends an email with the QR code of checkInToken embedded as checkInQRCodeName,
+// and the iCalendar file calendar attached unless it is nil
+func (e *EmailSender) sendWithCheckInQRCode(to, subject, htmlBody, checkInToken string, calendar []byte) error {
+	png, err := GenerateCheckInQRCode(checkInToken)
+	if err != nil {
+		return fmt.Errorf("failed to generate check-in QR code: %w", err)
+	}
+
+	m := e.newMessage(to, subject, htmlBody)
+	m.Embed(checkInQRCodeName, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(png)
+		return err
+	}))
+	if calendar != nil {
+		attachCalendar(m, calendar)
+	}
+
+	return e.send(m)
+}
+
+// attachCalendar attaches an iCalendar file that adds or updates the event in the recipient's calendar
+func attachCalendar(m *gomail.Message, calendar []byte) {
+	m.Attach(calendarAttachmentName,
+		gomail.SetHeader(map[string][]string{"Content-Type": {ICalendarContentType + "; method=PUBLISH"}}),
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(calendar)
+			return err
+		}),
+	)
+}
+
+func (e *EmailSender) newMessage(to, subject, htmlBody string) *gomail.Message {
 	m := gomail.NewMessage()
 	m.SetHeader("From", e.smtpUser)
 	m.SetHeader("To", to)
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", htmlBody)
+	return m
+}
 
+func (e *EmailSender) send(m *gomail.Message) error {
 	d := gomail.NewDialer(e.smtpHost, e.smtpPort, e.smtpUser, e.smtpPassword)
 
 	if err := d.DialAndSend(m); err != nil {
@@ -46,8 +91,8 @@ func (e *EmailSender) SendEmail(to, subject, htmlBody string) error {
 
 // Email templates
 
-// SendRegistrationConfirmation sends registration confirmation email
-func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle string, eventDate time.Time, registrationID string) error {
+// SendRegistrationConfirmation sends registration confirmation email with the event attached as an iCalendar file
+func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle string, eventDate time.Time, registrationID, checkInToken string, calendar []byte) error {
 	subject := fmt.Sprintf("Konfirmasi Pendaftaran: %s", eventTitle)
 
 	tmpl := `
@@ -61,6 +106,7 @@ func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle stri
 		.content { padding: 20px; background-color: #f9f9f9; }
 		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
 		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #4CAF50; }
+		.qr-code { text-align: center; margin: 15px 0; }
 	</style>
 </head>
 <body>
@@ -78,8 +124,14 @@ func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle stri
 				<p>🎫 <strong>ID Pendaftaran:</strong> {{.RegistrationID}}</p>
 			</div>
 
+			<div class="qr-code">
+				<img src="cid:{{.QRCode}}" alt="QR Code Check-in" width="200" height="200">
+				<p><strong>Tunjukkan QR code ini kepada panitia saat check-in.</strong></p>
+			</div>
+
+			<p>📆 Buka lampiran <strong>event.ics</strong> untuk menambahkan event ini ke kalender Anda.</p>
+
 			<p>Anda akan menerima email reminder H-1 sebelum event dimulai.</p>
-			<p><strong>Simpan ID pendaftaran ini untuk keperluan check-in.</strong></p>
 
 			<p>Sampai jumpa di event!</p>
 		</div>
@@ -96,11 +148,13 @@ func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle stri
 		EventTitle     string
 		EventDate      string
 		RegistrationID string
+		QRCode         string
 	}{
 		UserName:       userName,
 		EventTitle:     eventTitle,
 		EventDate:      eventDate.Format("Monday, 02 January 2006 - 15:04 WIB"),
 		RegistrationID: registrationID,
+		QRCode:         checkInQRCodeName,
 	}
 
 	var body bytes.Buffer
@@ -109,7 +163,7 @@ func (e *EmailSender) SendRegistrationConfirmation(to, userName, eventTitle stri
 		return err
 	}
 
-	return e.SendEmail(to, subject, body.String())
+	return e.sendWithCheckInQRCode(to, subject, body.String(), checkInToken, calendar)
 }
 
 // SendWaitlistNotification sends waitlist notification email
@@ -239,6 +293,128 @@ func (e *EmailSender) SendWaitlistPromotion(to, userName, eventTitle string, eve
 	return e.SendEmail(to, subject, body.String())
 }
 
+// SendWaitlistOffer sends an email offering a freed seat to a waitlisted user
+func (e *EmailSender) SendWaitlistOffer(to, userName, eventTitle string, eventDate, expiresAt time.Time, registrationID string) error {
+	subject := fmt.Sprintf("🎟️ Slot Tersedia: %s", eventTitle)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #2196F3; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #2196F3; }
+		.warning { color: #f44336; font-weight: bold; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>🎟️ Ada Slot untuk Anda!</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Seorang peserta membatalkan pendaftaran dan slotnya kami tawarkan kepada Anda:</p>
+
+			<div class="info-box">
+				<h2>{{.EventTitle}}</h2>
+				<p>📅 <strong>Tanggal:</strong> {{.EventDate}}</p>
+				<p>🎫 <strong>ID Pendaftaran:</strong> {{.RegistrationID}}</p>
+				<p>⏰ <strong>Klaim sebelum:</strong> {{.ExpiresAt}}</p>
+			</div>
+
+			<p>Buka menu <strong>Pendaftaran Saya</strong> dan klaim slot ini untuk mengonfirmasi kehadiran Anda.</p>
+			<p class="warning">Jika tidak diklaim sebelum batas waktu, slot akan ditawarkan ke peserta berikutnya di waiting list.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName       string
+		EventTitle     string
+		EventDate      string
+		ExpiresAt      string
+		RegistrationID string
+	}{
+		UserName:       userName,
+		EventTitle:     eventTitle,
+		EventDate:      eventDate.Format("Monday, 02 January 2006 - 15:04 WIB"),
+		ExpiresAt:      expiresAt.Format("Monday, 02 January 2006 - 15:04 WIB"),
+		RegistrationID: registrationID,
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return e.SendEmail(to, subject, body.String())
+}
+
+// SendWaitlistOfferExpired tells a user their seat offer lapsed without being claimed
+func (e *EmailSender) SendWaitlistOfferExpired(to, userName, eventTitle string) error {
+	subject := fmt.Sprintf("Penawaran Slot Berakhir: %s", eventTitle)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #9E9E9E; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>⌛ Penawaran Slot Berakhir</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Slot untuk event <strong>{{.EventTitle}}</strong> tidak diklaim sebelum batas waktu, sehingga telah diteruskan ke peserta berikutnya di waiting list.</p>
+
+			<p>Anda dapat mendaftar kembali jika masih ada slot tersedia.</p>
+
+			<p>Terima kasih.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName   string
+		EventTitle string
+	}{
+		UserName:   userName,
+		EventTitle: eventTitle,
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return e.SendEmail(to, subject, body.String())
+}
+
 // SendCancellationConfirmation sends cancellation confirmation email
 func (e *EmailSender) SendCancellationConfirmation(to, userName, eventTitle string) error {
 	subject := fmt.Sprintf("Pembatalan Pendaftaran: %s", eventTitle)
@@ -293,8 +469,137 @@ func (e *EmailSender) SendCancellationConfirmation(to, userName, eventTitle stri
 	return e.SendEmail(to, subject, body.String())
 }
 
+// SendEventCancellation tells a participant or waitlisted user that the event was cancelled
+func (e *EmailSender) SendEventCancellation(to, userName, eventTitle string, eventDate time.Time, reason string) error {
+	subject := fmt.Sprintf("Event Dibatalkan: %s", eventTitle)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #f44336; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #f44336; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>🚫 Event Dibatalkan</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Mohon maaf, event <strong>{{.EventTitle}}</strong> yang dijadwalkan pada {{.EventDate}} telah dibatalkan oleh penyelenggara.</p>
+
+			<div class="info-box">
+				<p><strong>Alasan:</strong></p>
+				<p>{{.Reason}}</p>
+			</div>
+
+			<p>Pendaftaran Anda untuk event ini otomatis dibatalkan. Anda tidak perlu melakukan apa pun.</p>
+
+			<p>Terima kasih atas pengertiannya.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName   string
+		EventTitle string
+		EventDate  string
+		Reason     string
+	}{
+		UserName:   userName,
+		EventTitle: eventTitle,
+		EventDate:  eventDate.Format("Monday, 02 January 2006 - 15:04 WIB"),
+		Reason:     reason,
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return e.SendEmail(to, subject, body.String())
+}
+
+// SendCertificate sends a participation certificate as a PDF attachment
+func (e *EmailSender) SendCertificate(to, userName, eventTitle, serialNumber string, pdf []byte) error {
+	subject := fmt.Sprintf("Sertifikat: %s", eventTitle)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #4CAF50; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #4CAF50; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>🎓 Sertifikat Anda Sudah Terbit</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Terima kasih telah menghadiri <strong>{{.EventTitle}}</strong>. Sertifikat keikutsertaan Anda terlampir pada email ini.</p>
+
+			<div class="info-box">
+				<p>📜 <strong>Nomor Sertifikat:</strong> {{.SerialNumber}}</p>
+			</div>
+
+			<p>Sertifikat juga dapat diunduh kembali kapan saja melalui halaman pendaftaran Anda.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName     string
+		EventTitle   string
+		SerialNumber string
+	}{
+		UserName:     userName,
+		EventTitle:   eventTitle,
+		SerialNumber: serialNumber,
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	m := e.newMessage(to, subject, body.String())
+	m.Attach(fmt.Sprintf("sertifikat-%s.pdf", serialNumber), gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(pdf)
+		return err
+	}))
+
+	return e.send(m)
+}
+
 // SendReminderEmail sends H-1 reminder email
-func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDate time.Time, location string, zoomLink *string, registrationID string) error {
+func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDate time.Time, location string, zoomLink *string, registrationID, checkInToken string) error {
 	subject := fmt.Sprintf("[Reminder] Event Besok: %s", eventTitle)
 
 	tmpl := `
@@ -310,6 +615,7 @@ func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDa
 		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #2196F3; }
 		.zoom-link { background-color: #4CAF50; color: white; padding: 10px; border-radius: 5px; text-align: center; margin: 10px 0; }
 		.zoom-link a { color: white; text-decoration: none; font-weight: bold; }
+		.qr-code { text-align: center; margin: 15px 0; }
 	</style>
 </head>
 <body>
@@ -338,7 +644,10 @@ func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDa
 
 			<p><strong>Jangan lupa untuk hadir!</strong></p>
 			{{if not .ZoomLink}}
-			<p>Tunjukkan Registration ID saat check-in.</p>
+			<div class="qr-code">
+				<img src="cid:{{.QRCode}}" alt="QR Code Check-in" width="200" height="200">
+				<p>Tunjukkan QR code ini kepada panitia saat check-in.</p>
+			</div>
 			{{end}}
 
 			<p>Sampai jumpa besok!</p>
@@ -358,6 +667,7 @@ func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDa
 		Location       string
 		ZoomLink       *string
 		RegistrationID string
+		QRCode         string
 	}{
 		UserName:       userName,
 		EventTitle:     eventTitle,
@@ -365,6 +675,7 @@ func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDa
 		Location:       location,
 		ZoomLink:       zoomLink,
 		RegistrationID: registrationID,
+		QRCode:         checkInQRCodeName,
 	}
 
 	var body bytes.Buffer
@@ -373,7 +684,12 @@ func (e *EmailSender) SendReminderEmail(to, userName, eventTitle string, eventDa
 		return err
 	}
 
-	return e.SendEmail(to, subject, body.String())
+	// Online events have no door to check in at
+	if zoomLink != nil && *zoomLink != "" {
+		return e.SendEmail(to, subject, body.String())
+	}
+
+	return e.sendWithCheckInQRCode(to, subject, body.String(), checkInToken, nil)
 }
 
 // SendWhitelistApproval sends whitelist approval email
@@ -498,8 +814,9 @@ func (e *EmailSender) SendWhitelistRejection(to, userName, reason string) error
 	return e.SendEmail(to, subject, body.String())
 }
 
-// SendEventUpdateNotification sends event update notification email
-func (e *EmailSender) SendEventUpdateNotification(to, userName, eventTitle string, eventDate time.Time, changes []string) error {
+// SendEventUpdateNotification sends event update notification email with the updated event
+// attached as an iCalendar file, which replaces the entry imported from earlier emails
+func (e *EmailSender) SendEventUpdateNotification(to, userName, eventTitle string, eventDate time.Time, changes []string, calendar []byte) error {
 	subject := fmt.Sprintf("📢 Update Event: %s", eventTitle)
 
 	tmpl := `
@@ -539,6 +856,8 @@ func (e *EmailSender) SendEventUpdateNotification(to, userName, eventTitle strin
 				</ul>
 			</div>
 
+			<p>📆 Buka lampiran <strong>event.ics</strong> untuk memperbarui event ini di kalender Anda.</p>
+
 			<p>Mohon cek kembali detail event di aplikasi Event Campus.</p>
 			<p>Terima kasih.</p>
 		</div>
@@ -568,5 +887,205 @@ func (e *EmailSender) SendEventUpdateNotification(to, userName, eventTitle strin
 		return err
 	}
 
+	m := e.newMessage(to, subject, body.String())
+	attachCalendar(m, calendar)
+
+	return e.send(m)
+}
+
+// SendEmailVerification sends email address verification link
+func (e *EmailSender) SendEmailVerification(to, userName, verifyURL string, expiresIn time.Duration) error {
+	subject := "Verifikasi Email Akun Event Campus"
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #2196F3; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.button { display: inline-block; background-color: #2196F3; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>📧 Verifikasi Email</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Terima kasih telah mendaftar di Event Campus. Silakan verifikasi alamat email Anda dengan menekan tombol di bawah ini:</p>
+
+			<p style="text-align: center;">
+				<a href="{{.VerifyURL}}" class="button">Verifikasi Email</a>
+			</p>
+
+			<p>Atau salin link berikut ke browser Anda:</p>
+			<p style="word-break: break-all;">{{.VerifyURL}}</p>
+
+			<p>Link ini berlaku selama <strong>{{.ExpiresIn}}</strong>. Anda belum dapat mendaftar event sebelum email terverifikasi.</p>
+			<p>Jika Anda tidak merasa membuat akun, abaikan email ini.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName  string
+		VerifyURL string
+		ExpiresIn string
+	}{
+		UserName:  userName,
+		VerifyURL: verifyURL,
+		ExpiresIn: formatDuration(expiresIn),
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return e.SendEmail(to, subject, body.String())
+}
+
+// SendPasswordReset sends password reset link
+func (e *EmailSender) SendPasswordReset(to, userName, resetURL string, expiresIn time.Duration) error {
+	subject := "Reset Password Akun Event Campus"
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #FF9800; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.button { display: inline-block; background-color: #FF9800; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>🔑 Reset Password</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Kami menerima permintaan untuk mereset password akun Anda. Tekan tombol di bawah ini untuk membuat password baru:</p>
+
+			<p style="text-align: center;">
+				<a href="{{.ResetURL}}" class="button">Reset Password</a>
+			</p>
+
+			<p>Atau salin link berikut ke browser Anda:</p>
+			<p style="word-break: break-all;">{{.ResetURL}}</p>
+
+			<p>Link ini hanya dapat digunakan satu kali dan berlaku selama <strong>{{.ExpiresIn}}</strong>. Setelah password diubah, semua sesi login Anda akan diakhiri.</p>
+			<p>Jika Anda tidak meminta reset password, abaikan email ini. Password Anda tidak akan berubah.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName  string
+		ResetURL  string
+		ExpiresIn string
+	}{
+		UserName:  userName,
+		ResetURL:  resetURL,
+		ExpiresIn: formatDuration(expiresIn),
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return e.SendEmail(to, subject, body.String())
+}
+
+// SendAccountLockedNotification warns the user that their account was locked after repeated failed logins
+func (e *EmailSender) SendAccountLockedNotification(to, userName string, failedAttempts int, lockedUntil time.Time, ipAddress string) error {
+	subject := "⚠️ Akun Event Campus Dikunci Sementara"
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+	<style>
+		body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+		.container { max-width: 600px; margin: 0 auto; padding: 20px; }
+		.header { background-color: #f44336; color: white; padding: 20px; text-align: center; }
+		.content { padding: 20px; background-color: #f9f9f9; }
+		.footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+		.info-box { background-color: white; padding: 15px; margin: 15px 0; border-left: 4px solid #f44336; }
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h1>🔒 Akun Dikunci Sementara</h1>
+		</div>
+		<div class="content">
+			<p>Halo <strong>{{.UserName}}</strong>,</p>
+			<p>Kami mendeteksi <strong>{{.FailedAttempts}} kali percobaan login gagal</strong> pada akun Anda. Untuk melindungi akun Anda, login dikunci sementara.</p>
+
+			<div class="info-box">
+				<p>🕐 <strong>Dikunci sampai:</strong> {{.LockedUntil}}</p>
+				{{if .IPAddress}}<p>🌐 <strong>Alamat IP terakhir:</strong> {{.IPAddress}}</p>{{end}}
+			</div>
+
+			<p>Jika ini bukan Anda, segera reset password Anda melalui menu <strong>Lupa Password</strong> setelah kunci berakhir, atau hubungi admin untuk membuka kunci akun.</p>
+			<p>Jika Anda hanya lupa password, Anda dapat mencoba lagi setelah waktu di atas.</p>
+		</div>
+		<div class="footer">
+			<p>Event Campus - Platform Manajemen Event Kampus</p>
+		</div>
+	</div>
+</body>
+</html>
+	`
+
+	data := struct {
+		UserName       string
+		FailedAttempts int
+		LockedUntil    string
+		IPAddress      string
+	}{
+		UserName:       userName,
+		FailedAttempts: failedAttempts,
+		LockedUntil:    lockedUntil.Format("Monday, 02 January 2006 - 15:04 WIB"),
+		IPAddress:      ipAddress,
+	}
+
+	var body bytes.Buffer
+	t := template.Must(template.New("email").Parse(tmpl))
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
 	return e.SendEmail(to, subject, body.String())
 }
+
+// formatDuration renders a duration in Indonesian for email copy
+func formatDuration(d time.Duration) string {
+	if d >= time.Hour {
+		return fmt.Sprintf("%d jam", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d menit", int(d.Minutes()))
+}
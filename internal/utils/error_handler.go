@@ -3,19 +3,23 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Custom error types
 var (
-	ErrBadRequest   = errors.New("bad request")
-	ErrUnauthorized = errors.New("unauthorized")
-	ErrForbidden    = errors.New("forbidden")
-	ErrNotFound     = errors.New("not found")
-	ErrConflict     = errors.New("conflict")
-	ErrInternal     = errors.New("internal server error")
+	ErrBadRequest      = errors.New("bad request")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrTooManyRequests = errors.New("too many requests")
+	ErrInternal        = errors.New("internal server error")
 )
 
 // AppError represents an application error with HTTP status code
@@ -23,6 +27,7 @@ type AppError struct {
 	Err        error
 	Message    string
 	StatusCode int
+	RetryAfter time.Duration
 }
 
 // Error implements error interface
@@ -78,6 +83,25 @@ func NewConflictError(message string) *AppError {
 	}
 }
 
+// NewTooManyRequestsError creates a too many requests error telling the client when to retry
+func NewTooManyRequestsError(message string, retryAfter time.Duration) *AppError {
+	return &AppError{
+		Err:        ErrTooManyRequests,
+		Message:    message,
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: retryAfter,
+	}
+}
+
+// RetryAfterSeconds formats a duration for the Retry-After header, rounded up to whole seconds
+func RetryAfterSeconds(d time.Duration) string {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
+
 // NewInternalError creates an internal server error
 func NewInternalError(message string) *AppError {
 	return &AppError{
@@ -93,6 +117,9 @@ func HandleError(c *gin.Context, err error) {
 
 	// Check if error is AppError
 	if errors.As(err, &appErr) {
+		if appErr.RetryAfter > 0 {
+			c.Header("Retry-After", RetryAfterSeconds(appErr.RetryAfter))
+		}
 		c.JSON(appErr.StatusCode, gin.H{
 			"success": false,
 			"message": "Request failed",
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Spreadsheet export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// exportSheetName is the worksheet XLSX exports are written to
+const exportSheetName = "Sheet1"
+
+// ExportContentType returns the MIME type of an export format
+func ExportContentType(format string) string {
+	if format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// WriteExport writes a spreadsheet with a header row followed by the rows passed to write.
+// CSV is streamed to w as it is produced. XLSX is streamed into the workbook, which excelize
+// spills to a temporary file when large, and written to w once rows returns.
+func WriteExport(w io.Writer, format string, header []string, rows func(write func(values []string) error) error) error {
+	switch format {
+	case ExportFormatCSV:
+		return writeCSVExport(w, header, rows)
+	case ExportFormatXLSX:
+		return writeXLSXExport(w, header, rows)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func writeCSVExport(w io.Writer, header []string, rows func(write func(values []string) error) error) error {
+	writer := csv.NewWriter(w)
+	write := func(values []string) error {
+		escaped := make([]string, len(values))
+		for i, value := range values {
+			escaped[i] = escapeCSVFormula(value)
+		}
+		return writer.Write(escaped)
+	}
+
+	if err := write(header); err != nil {
+		return err
+	}
+
+	if err := rows(write); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeCSVFormula keeps spreadsheet apps from evaluating user-provided values as formulas
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func writeXLSXExport(w io.Writer, header []string, rows func(write func(values []string) error) error) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	stream, err := file.NewStreamWriter(exportSheetName)
+	if err != nil {
+		return fmt.Errorf("failed to create xlsx stream: %w", err)
+	}
+
+	rowNum := 0
+	write := func(values []string) error {
+		rowNum++
+		cell, err := excelize.CoordinatesToCellName(1, rowNum)
+		if err != nil {
+			return err
+		}
+
+		row := make([]interface{}, len(values))
+		for i, value := range values {
+			row[i] = value
+		}
+		return stream.SetRow(cell, row)
+	}
+
+	if err := write(header); err != nil {
+		return err
+	}
+
+	if err := rows(write); err != nil {
+		return err
+	}
+
+	if err := stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush xlsx stream: %w", err)
+	}
+
+	if _, err := file.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+
+	return nil
+}
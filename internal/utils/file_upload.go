@@ -118,6 +118,92 @@ func (u *FileUploader) SaveDocument(file *multipart.FileHeader) (string, error)
 	return filepath.Join("documents", filename), nil
 }
 
+// SaveAvatar saves a user profile picture
+func (u *FileUploader) SaveAvatar(file *multipart.FileHeader) (string, error) {
+	// Validate file size
+	if file.Size > u.maxSize {
+		return "", ErrFileTooLarge
+	}
+
+	// Validate file type
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+		return "", ErrInvalidFileType
+	}
+
+	// Generate unique filename
+	filename := fmt.Sprintf("%s%s", uuid.New().String(), ext)
+	avatarPath := filepath.Join(u.uploadPath, "avatars", filename)
+
+	// Create directory if not exists
+	if err := os.MkdirAll(filepath.Dir(avatarPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Save file
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(avatarPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", fmt.Errorf("failed to save file: %w", err)
+	}
+
+	// Return relative path
+	return filepath.Join("avatars", filename), nil
+}
+
+// SaveCertificateBackground saves the background image of a certificate template
+func (u *FileUploader) SaveCertificateBackground(file *multipart.FileHeader) (string, error) {
+	// Validate file size
+	if file.Size > u.maxSize {
+		return "", ErrFileTooLarge
+	}
+
+	// Validate file type
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+		return "", ErrInvalidFileType
+	}
+
+	// Generate unique filename
+	filename := fmt.Sprintf("%s%s", uuid.New().String(), ext)
+	backgroundPath := filepath.Join(u.uploadPath, "certificates", filename)
+
+	// Create directory if not exists
+	if err := os.MkdirAll(filepath.Dir(backgroundPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Save file
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(backgroundPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", fmt.Errorf("failed to save file: %w", err)
+	}
+
+	// Return relative path
+	return filepath.Join("certificates", filename), nil
+}
+
 // DeleteFile deletes a file from storage
 func (u *FileUploader) DeleteFile(relativePath string) error {
 	if relativePath == "" {
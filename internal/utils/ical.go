@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICalendarContentType is the MIME type of iCalendar downloads and feeds
+const ICalendarContentType = "text/calendar; charset=utf-8"
+
+// icalTimeFormat writes times in UTC, so calendars need no VTIMEZONE
+const icalTimeFormat = "20060102T150405Z"
+
+// icalLineLength is the longest content line RFC 5545 allows, in octets
+const icalLineLength = 75
+
+// icalTextEscaper escapes TEXT property values (RFC 5545 section 3.3.11)
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+// CalendarEvent is an event as written to an iCalendar VEVENT. Calendar apps replace an
+// entry they imported with one of the same UID and a higher Sequence.
+type CalendarEvent struct {
+	UID          string
+	Sequence     int
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Latitude     *float64
+	Longitude    *float64
+	Start        time.Time
+	End          time.Time
+	Cancelled    bool
+	LastModified time.Time
+}
+
+// GenerateICalendar writes events as an RFC 5545 calendar. name is the calendar name shown
+// by apps subscribed to a feed and may be empty.
+func GenerateICalendar(name string, events []CalendarEvent) []byte {
+	var buf bytes.Buffer
+	write := func(name, value string) {
+		writeICalLine(&buf, name+":"+value)
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", "-//Event Campus//Event Campus API//ID")
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	if name != "" {
+		write("X-WR-CALNAME", icalTextEscaper.Replace(name))
+	}
+
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+	for _, event := range events {
+		write("BEGIN", "VEVENT")
+		write("UID", event.UID)
+		write("DTSTAMP", stamp)
+		write("SEQUENCE", strconv.Itoa(event.Sequence))
+		write("DTSTART", event.Start.UTC().Format(icalTimeFormat))
+		write("DTEND", event.End.UTC().Format(icalTimeFormat))
+		write("SUMMARY", icalTextEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			write("DESCRIPTION", icalTextEscaper.Replace(event.Description))
+		}
+		if event.Location != "" {
+			write("LOCATION", icalTextEscaper.Replace(event.Location))
+		}
+		if event.URL != "" {
+			write("URL", strings.NewReplacer("\r", "", "\n", "").Replace(event.URL))
+		}
+		if event.Latitude != nil && event.Longitude != nil {
+			write("GEO", fmt.Sprintf("%f;%f", *event.Latitude, *event.Longitude))
+		}
+		if event.Cancelled {
+			write("STATUS", "CANCELLED")
+		} else {
+			write("STATUS", "CONFIRMED")
+		}
+		if !event.LastModified.IsZero() {
+			write("LAST-MODIFIED", event.LastModified.UTC().Format(icalTimeFormat))
+		}
+		write("END", "VEVENT")
+	}
+
+	write("END", "VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// writeICalLine writes a content line folded at icalLineLength octets, never inside a
+// UTF-8 sequence, continuation lines starting with a space
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineLength - 1 // the leading space counts
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
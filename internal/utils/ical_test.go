@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// unfoldICal joins folded continuation lines back into their content line
+func unfoldICal(calendar string) string {
+	return strings.ReplaceAll(calendar, "\r\n ", "")
+}
+
+func testCalendarEvent() CalendarEvent {
+	start := time.Date(2026, 3, 14, 2, 0, 0, 0, time.UTC)
+	return CalendarEvent{
+		UID:     "event-1@event-campus",
+		Summary: "Seminar",
+		Start:   start,
+		End:     start.Add(2 * time.Hour),
+	}
+}
+
+func TestGenerateICalendarEscapesText(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+		want    string
+	}{
+		{"plain", "Seminar Nasional", "Seminar Nasional"},
+		{"comma", "Workshop, Seminar", `Workshop\, Seminar`},
+		{"semicolon", "Hari 1; Hari 2", `Hari 1\; Hari 2`},
+		{"backslash", `C:\Users`, `C:\\Users`},
+		{"newline", "Baris 1\nBaris 2", `Baris 1\nBaris 2`},
+		{"crlf", "Baris 1\r\nBaris 2", `Baris 1\nBaris 2`},
+		{"carriage return", "Baris 1\rBaris 2", "Baris 1Baris 2"},
+		{"escaped once", `a\,b`, `a\\\,b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testCalendarEvent()
+			event.Summary = tt.summary
+
+			calendar := unfoldICal(string(GenerateICalendar("", []CalendarEvent{event})))
+			if !strings.Contains(calendar, "\r\nSUMMARY:"+tt.want+"\r\n") {
+				t.Errorf("SUMMARY of %q not written as %q:\n%s", tt.summary, tt.want, calendar)
+			}
+		})
+	}
+}
+
+func TestWriteICalLineFolds(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int
+	}{
+		{"short", "SUMMARY:Seminar", 1},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67), 1},
+		{"76 octets", "SUMMARY:" + strings.Repeat("a", 68), 2},
+		{"long ascii", "DESCRIPTION:" + strings.Repeat("Kegiatan mahasiswa ", 20), 6},
+		{
+			"long indonesian multibyte",
+			"SUMMARY:" + strings.Repeat("Seminar Nasional “Kewirausahaan Kreatif” — Café Yogyakarta 🎓 ", 4),
+			0,
+		},
+		{"multibyte at the fold", "SUMMARY:" + strings.Repeat("a", 66) + "é" + strings.Repeat("a", 10), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeICalLine(&buf, tt.line)
+			written := buf.String()
+
+			if !strings.HasSuffix(written, "\r\n") {
+				t.Fatalf("line does not end with CRLF: %q", written)
+			}
+			if strings.Count(written, "\n") != strings.Count(written, "\r\n") {
+				t.Errorf("line has a bare LF: %q", written)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(written, "\r\n"), "\r\n")
+			if tt.lines > 0 && len(lines) != tt.lines {
+				t.Errorf("folded into %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, line := range lines {
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+				}
+				if i == 0 {
+					if len(line) > icalLineLength {
+						t.Errorf("first line is %d octets, want at most %d", len(line), icalLineLength)
+					}
+					continue
+				}
+				if !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, line)
+				}
+				if content := len(line) - 1; content > icalLineLength-1 {
+					t.Errorf("continuation line %d carries %d octets, want at most %d", i, content, icalLineLength-1)
+				}
+			}
+
+			if got := unfoldICal(written); got != tt.line+"\r\n" {
+				t.Errorf("unfolded line differs:\ngot  %q\nwant %q", got, tt.line+"\r\n")
+			}
+		})
+	}
+}
+
+func TestGenerateICalendarSequenceAndStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		sequence  int
+		cancelled bool
+		want      []string
+	}{
+		{"new event", 0, false, []string{"SEQUENCE:0", "STATUS:CONFIRMED"}},
+		{"rescheduled", 2, false, []string{"SEQUENCE:2", "STATUS:CONFIRMED"}},
+		{"cancelled", 3, true, []string{"SEQUENCE:3", "STATUS:CANCELLED"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testCalendarEvent()
+			event.Sequence = tt.sequence
+			event.Cancelled = tt.cancelled
+
+			calendar := string(GenerateICalendar("Event Campus", []CalendarEvent{event}))
+			if !strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(calendar, "END:VCALENDAR\r\n") {
+				t.Fatalf("calendar is not wrapped in VCALENDAR:\n%s", calendar)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(calendar, "\r\n"+want+"\r\n") {
+					t.Errorf("calendar has no %q line:\n%s", want, calendar)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ImportRow is one data row of an uploaded CSV, keyed by lowercased header name
+type ImportRow struct {
+	Line   int
+	Values map[string]string
+}
+
+// ReadCSVImport reads an uploaded CSV with a header row. Excel's UTF-8 byte order mark is
+// skipped and semicolon separated files, as saved by Excel in some locales, are accepted.
+// It fails once the file has more than maxRows data rows.
+func ReadCSVImport(r io.Reader, maxRows int) (header []string, rows []ImportRow, err error) {
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, err := buffered.Peek(buffered.Buffered()); err == nil {
+		if line, _, _ := strings.Cut(string(firstLine), "\n"); strings.Count(line, ";") > strings.Count(line, ",") {
+			reader.Comma = ';'
+		}
+	}
+
+	header, err = reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) == maxRows {
+			return nil, nil, fmt.Errorf("file has more than %d rows", maxRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Line: line, Values: make(map[string]string, len(header))}
+		for i, value := range record {
+			if i < len(header) {
+				row.Values[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return header, rows, nil
+}
+
+// isBlankRecord reports whether every field of a CSV record is empty, e.g. trailing lines
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
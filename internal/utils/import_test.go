@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSVImport(t *testing.T) {
+	tests := []struct {
+		name   string
+		csv    string
+		header []string
+		rows   []ImportRow
+	}{
+		{
+			name:   "comma separated",
+			csv:    "email,notes\na@uii.ac.id,hadir\n",
+			header: []string{"email", "notes"},
+			rows:   []ImportRow{{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "hadir"}}},
+		},
+		{
+			name:   "byte order mark",
+			csv:    "\xef\xbb\xbfemail,notes\na@uii.ac.id,hadir\n",
+			header: []string{"email", "notes"},
+			rows:   []ImportRow{{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "hadir"}}},
+		},
+		{
+			name:   "semicolon separated",
+			csv:    "email;notes\na@uii.ac.id;hadir, terlambat\n",
+			header: []string{"email", "notes"},
+			rows:   []ImportRow{{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "hadir, terlambat"}}},
+		},
+		{
+			name:   "byte order mark and semicolons",
+			csv:    "\xef\xbb\xbfEmail ; Notes\r\na@uii.ac.id; hadir\r\n",
+			header: []string{"email", "notes"},
+			rows:   []ImportRow{{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "hadir"}}},
+		},
+		{
+			name:   "semicolons in comma separated values",
+			csv:    "email,notes\na@uii.ac.id,\"sesi 1; sesi 2\"\n",
+			header: []string{"email", "notes"},
+			rows:   []ImportRow{{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "sesi 1; sesi 2"}}},
+		},
+		{
+			name:   "line numbers skip blank and multiline rows",
+			csv:    "email,notes\na@uii.ac.id,\"baris 1\nbaris 2\"\n\n,\nb@uii.ac.id,\nc@uii.ac.id\n",
+			header: []string{"email", "notes"},
+			rows: []ImportRow{
+				{Line: 2, Values: map[string]string{"email": "a@uii.ac.id", "notes": "baris 1\nbaris 2"}},
+				{Line: 6, Values: map[string]string{"email": "b@uii.ac.id", "notes": ""}},
+				{Line: 7, Values: map[string]string{"email": "c@uii.ac.id"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, rows, err := ReadCSVImport(strings.NewReader(tt.csv), 10)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !reflect.DeepEqual(header, tt.header) {
+				t.Errorf("header = %q, want %q", header, tt.header)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestReadCSVImportMaxRows(t *testing.T) {
+	csv := "email\na@uii.ac.id\nb@uii.ac.id\n\nc@uii.ac.id\n"
+
+	_, rows, err := ReadCSVImport(strings.NewReader(csv), 3)
+	if err != nil {
+		t.Fatalf("3 rows with a limit of 3: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("got %d rows, want 3", len(rows))
+	}
+
+	if _, _, err := ReadCSVImport(strings.NewReader(csv), 2); err == nil {
+		t.Error("3 rows accepted with a limit of 2")
+	}
+}
+
+func TestReadCSVImportEmpty(t *testing.T) {
+	for _, csv := range []string{"", "\xef\xbb\xbf"} {
+		if _, _, err := ReadCSVImport(strings.NewReader(csv), 10); err == nil {
+			t.Errorf("empty file %q accepted", csv)
+		}
+	}
+}
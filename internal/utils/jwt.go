@@ -15,18 +15,20 @@ var (
 
 // JWTClaims represents JWT claims
 type JWTClaims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a new JWT token
-func GenerateToken(userID uuid.UUID, email, role, secret string, expiration time.Duration) (string, error) {
+// GenerateToken generates a new JWT access token bound to a session
+func GenerateToken(userID, sessionID uuid.UUID, email, role, secret string, expiration time.Duration) (string, error) {
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		SessionID: sessionID,
+		Email:     email,
+		Role:      role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -0,0 +1,21 @@
+-- Sessions for refresh token rotation and server-side revocation
+-- Execute this in Supabase SQL Editor after 001_initial_schema.sql
+
+-- Table: sessions
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash VARCHAR(64) UNIQUE NOT NULL,
+    previous_token_hash VARCHAR(64),
+    user_agent VARCHAR(500),
+    ip_address VARCHAR(64),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    last_used_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token ON sessions(previous_token_hash);
+
+COMMENT ON TABLE sessions IS 'Login sessions holding hashed refresh tokens';
@@ -0,0 +1,21 @@
+-- Email verification and password reset tokens
+-- Execute this in Supabase SQL Editor after 002_sessions.sql
+
+-- Existing accounts are backfilled as verified so enabling verification does not lock them out
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP DEFAULT NOW();
+ALTER TABLE users ALTER COLUMN email_verified_at DROP DEFAULT;
+
+-- Table: user_tokens
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    purpose VARCHAR(30) NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens(user_id, purpose);
+
+COMMENT ON TABLE user_tokens IS 'Single-use hashed tokens for email verification and password reset';
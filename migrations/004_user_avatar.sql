@@ -0,0 +1,4 @@
+-- Profile picture for users
+-- Execute this in Supabase SQL Editor after 003_email_verification_password_reset.sql
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_path VARCHAR(500);
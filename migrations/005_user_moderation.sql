@@ -0,0 +1,6 @@
+-- Admin account moderation: suspension and soft delete
+-- Execute this in Supabase SQL Editor after 004_user_avatar.sql
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
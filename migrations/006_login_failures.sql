@@ -0,0 +1,15 @@
+-- Failed login tracking for brute-force protection
+-- Execute this in Supabase SQL Editor after 005_user_moderation.sql
+
+-- Table: login_failures
+-- One row per account (lowercased email) and per client IP
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('account', 'ip')),
+    key VARCHAR(255) NOT NULL,
+    failure_count INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, key)
+);
+
+COMMENT ON TABLE login_failures IS 'Consecutive failed logins per account and per IP with backoff lockout';
@@ -0,0 +1,8 @@
+-- Indexes backing the sort keys of paginated event and registration listings
+-- Execute this in Supabase SQL Editor after 006_login_failures.sql
+
+-- Keyset pagination orders by (sort column, id)
+CREATE INDEX IF NOT EXISTS idx_events_deadline ON events(registration_deadline, id);
+CREATE INDEX IF NOT EXISTS idx_events_popularity ON events(current_participants, id);
+CREATE INDEX IF NOT EXISTS idx_events_created ON events(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_registrations_user_registered ON registrations(user_id, registered_at, id);
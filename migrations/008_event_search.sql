@@ -0,0 +1,18 @@
+-- Full-text event search with typo-tolerant fallback
+-- Execute this in Supabase SQL Editor after 007_list_sort_indexes.sql
+
+-- Trigram similarity for misspelled search terms
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Titles weigh more than descriptions, both indexed with the Indonesian and English stemmers
+ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('indonesian', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_events_search ON events USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_events_title_trgm ON events USING GIN(title gin_trgm_ops);
+
+COMMENT ON COLUMN events.search_vector IS 'Weighted title (A) and description (B) lexemes for GET /events/search';
@@ -0,0 +1,15 @@
+-- Waitlist positions and seat offers with claim deadlines
+-- Execute this in Supabase SQL Editor after 008_event_search.sql
+
+-- A freed seat can be held for the next waitlisted user until offer_expires_at
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS offer_expires_at TIMESTAMP;
+
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('registered', 'waitlist', 'offered', 'cancelled', 'attended'));
+
+-- Waitlist order per event, and the scheduler's scan for expired offers
+CREATE INDEX IF NOT EXISTS idx_registrations_waitlist ON registrations(event_id, registered_at, id) WHERE status = 'waitlist';
+CREATE INDEX IF NOT EXISTS idx_registrations_offer_expiry ON registrations(offer_expires_at) WHERE status = 'offered';
+
+COMMENT ON COLUMN registrations.offer_expires_at IS 'Claim deadline of an offered seat, the scheduler passes it on to the next waitlisted user after this';
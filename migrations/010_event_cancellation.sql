@@ -0,0 +1,11 @@
+-- Event cancellation with a reason, cascaded to registrations
+-- Execute this in Supabase SQL Editor after 009_waitlist_offers.sql
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+
+-- Set when a registration was cancelled on the user's behalf, e.g. because the event was cancelled
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+COMMENT ON COLUMN events.cancellation_reason IS 'Reason given by the organizer when cancelling the event';
+COMMENT ON COLUMN registrations.cancellation_reason IS 'Why the registration was cancelled when the user did not cancel it themselves';
@@ -0,0 +1,13 @@
+-- Self check-in with a rotating event code
+-- Execute this in Supabase SQL Editor after 010_event_cancellation.sql
+
+-- Time-boxed window in which registered participants can check themselves in
+ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_opens_at TIMESTAMP;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_closes_at TIMESTAMP;
+
+-- Who recorded the attendance: the organizer, or the participant for self check-in
+ALTER TABLE attendances ADD COLUMN IF NOT EXISTS marked_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN events.check_in_opens_at IS 'Start of the self check-in window opened by the organizer';
+COMMENT ON COLUMN events.check_in_closes_at IS 'End of the self check-in window, never after end_date';
+COMMENT ON COLUMN attendances.marked_by IS 'User who recorded the attendance, equal to the participant for self check-in';
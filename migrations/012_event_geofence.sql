@@ -0,0 +1,15 @@
+-- Geofenced self check-in for offline events
+-- Execute this in Supabase SQL Editor after 011_self_check_in.sql
+
+-- Optional venue coordinates; self check-in is only accepted within the radius
+ALTER TABLE events ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS check_in_radius_meters INTEGER;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_geofence_check;
+ALTER TABLE events ADD CONSTRAINT events_geofence_check CHECK (
+    (latitude IS NULL AND longitude IS NULL AND check_in_radius_meters IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180 AND check_in_radius_meters > 0)
+);
+
+COMMENT ON COLUMN events.check_in_radius_meters IS 'Self check-in is rejected farther than this from latitude/longitude';
@@ -0,0 +1,19 @@
+-- Audit trail of attendance changes
+-- Execute this in Supabase SQL Editor after 012_event_geofence.sql
+
+-- Table: attendance_history
+-- attendance_id is kept without a foreign key so removals stay on record
+CREATE TABLE IF NOT EXISTS attendance_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attendance_id UUID NOT NULL,
+    registration_id UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('marked', 'notes_updated', 'removed')),
+    old_notes TEXT,
+    new_notes TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_history_registration ON attendance_history(registration_id, changed_at);
+
+COMMENT ON TABLE attendance_history IS 'Who marked, edited or removed each attendance and when';
@@ -0,0 +1,30 @@
+-- Participation certificates
+-- Execute this in Supabase SQL Editor after 013_attendance_history.sql
+
+-- Table: certificate_templates
+-- One template per event: a background image and where each value is placed on it
+CREATE TABLE IF NOT EXISTS certificate_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID UNIQUE NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    background_path VARCHAR(500) NOT NULL,
+    fields JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Table: certificates
+-- Issued to attended registrations once the event is completed
+CREATE TABLE IF NOT EXISTS certificates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    registration_id UUID UNIQUE NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    serial_number VARCHAR(32) UNIQUE NOT NULL,
+    participant_name VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    emailed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_certificates_not_emailed ON certificates(issued_at) WHERE emailed_at IS NULL;
+
+COMMENT ON TABLE certificate_templates IS 'Certificate background and field placements per event';
+COMMENT ON TABLE certificates IS 'Participation certificates with their public serial numbers';
+COMMENT ON COLUMN certificates.participant_name IS 'Name printed on the certificate, as it was when issued';
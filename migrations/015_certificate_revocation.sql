@@ -0,0 +1,9 @@
+-- Certificate revocation
+-- Execute this in Supabase SQL Editor after 014_certificates.sql
+
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP;
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revocation_reason TEXT;
+
+COMMENT ON COLUMN certificates.revoked_at IS 'Set when the organizer revoked the certificate; public verification then reports it as revoked';
+COMMENT ON COLUMN certificates.revocation_reason IS 'Reason given by the organizer, shown on public verification';
@@ -0,0 +1,11 @@
+-- iCalendar downloads, email attachments and personal feeds
+-- Execute this in Supabase SQL Editor after 015_certificate_revocation.sql
+
+-- Bumped on every change so calendar apps replace the entry they imported
+ALTER TABLE events ADD COLUMN IF NOT EXISTS calendar_sequence INTEGER NOT NULL DEFAULT 0;
+
+-- Personal calendar feed, only the hash of the secret feed token is stored
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_token_hash VARCHAR(64) UNIQUE;
+
+COMMENT ON COLUMN events.calendar_sequence IS 'iCalendar SEQUENCE of the event, incremented when it is updated or cancelled';
+COMMENT ON COLUMN users.calendar_token_hash IS 'SHA-256 hash of the token in the user''s calendar feed URL';
@@ -56,14 +56,10 @@ PROFILE_RESPONSE=$(curl -s -X GET "$BASE_URL/profile" \
 validate_success "$PROFILE_RESPONSE" "true" "Get profile" || exit 1
 validate_field_exists "$PROFILE_RESPONSE" ".data.email" "Email" || exit 1
 validate_field_value "$PROFILE_RESPONSE" ".data.email" "$TEST_EMAIL" "Email match" || exit 1
+validate_field_exists "$PROFILE_RESPONSE" ".data.id" "User ID" || exit 1
 
-# Note: user_id may be empty string in response (backend issue)
-USER_ID=$(echo "$PROFILE_RESPONSE" | jq -r '.data.user_id // "unknown"')
-if [ "$USER_ID" == "" ] || [ "$USER_ID" == "unknown" ]; then
-  print_warning "User ID not available in profile response"
-else
-  print_info "User ID: $USER_ID"
-fi
+USER_ID=$(echo "$PROFILE_RESPONSE" | jq -r '.data.id')
+print_info "User ID: $USER_ID"
 
 echo ""
 
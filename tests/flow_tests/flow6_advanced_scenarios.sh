@@ -190,10 +190,14 @@ fi
 # 2. Cancel Published Event
 print_step "2. Cancel Published Event"
 # Reuse the event from Scenario 1 (ID: $EVENT_ID) which is published
-CANCEL_EVENT_RESP=$(curl -s -X DELETE "$BASE_URL/events/$EVENT_ID" \
-  -H "Authorization: Bearer $ORG_TOKEN")
+CANCEL_REASON="Speaker unavailable"
+CANCEL_EVENT_RESP=$(curl -s -X POST "$BASE_URL/events/$EVENT_ID/cancel" \
+  -H "Authorization: Bearer $ORG_TOKEN" \
+  -H "Content-Type: application/json" \
+  -d "{\"reason\": \"$CANCEL_REASON\"}")
 
 validate_success "$CANCEL_EVENT_RESP" "true" "Cancel published event" || exit 1
+validate_field_value "$CANCEL_EVENT_RESP" ".message" "Event cancelled successfully" "Cancel message" || exit 1
 
 # Verify status is 'cancelled'
 GET_EVENT_RESP=$(curl -s -X GET "$BASE_URL/events/$EVENT_ID" \
@@ -207,6 +211,16 @@ else
   exit 1
 fi
 
+validate_field_value "$GET_EVENT_RESP" ".data.cancellation_reason" "$CANCEL_REASON" "Cancellation reason" || exit 1
+
+# Cancelling again is rejected
+CANCEL_AGAIN_RESP=$(curl -s -X POST "$BASE_URL/events/$EVENT_ID/cancel" \
+  -H "Authorization: Bearer $ORG_TOKEN" \
+  -H "Content-Type: application/json" \
+  -d "{\"reason\": \"$CANCEL_REASON\"}")
+
+validate_success "$CANCEL_AGAIN_RESP" "false" "Cancel already cancelled event" || exit 1
+
 echo ""
 
 # ==========================================
@@ -57,7 +57,7 @@ get_user_id() {
   local response=$(curl -s -X GET "$BASE_URL/profile" \
     -H "Authorization: Bearer $token")
   
-  echo "$response" | jq -r '.data.user_id // empty'
+  echo "$response" | jq -r '.data.id // empty'
 }
 
 # Get user role from token